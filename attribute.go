@@ -1,5 +1,11 @@
 package errors
 
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+)
+
 type Attribute struct {
 	Min     interface{}   `json:"min"`
 	Max     interface{}   `json:"max"`
@@ -10,6 +16,195 @@ type Attribute struct {
 	Values  []interface{} `json:"values"`
 	Field   *string       `json:"field"`
 	Fields  []any         `json:"fields"`
+	// Extra holds custom keys. Keys colliding with the fields above are ignored when marshalling.
+	Extra map[string]any `json:"-"`
+}
+
+var attributeKeys = map[string]bool{
+	"min":     true,
+	"max":     true,
+	"in":      true,
+	"pattern": true,
+	"format":  true,
+	"value":   true,
+	"values":  true,
+	"field":   true,
+	"fields":  true,
+}
+
+// UnmarshalJSON decodes the known keys into the fields and keeps every other key in Extra
+func (attr *Attribute) UnmarshalJSON(data []byte) error {
+	// alias type drops the methods, otherwise json.Unmarshal recurses into this method
+	type plain Attribute
+	if err := json.Unmarshal(data, (*plain)(attr)); err != nil {
+		return err
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for k, v := range raw {
+		if attributeKeys[k] {
+			continue
+		}
+
+		if attr.Extra == nil {
+			attr.Extra = make(map[string]any)
+		}
+
+		attr.Extra[k] = v
+	}
+
+	return nil
+}
+
+func (attr Attribute) GetExtra(key string) (any, bool) {
+	v, ok := attr.Extra[key]
+
+	return v, ok
+}
+
+// MinInt returns Min as an int. Numbers decoded from the wire are float64, those are accepted if they're integral.
+func (attr Attribute) MinInt() (int, bool) {
+	return toInt(attr.Min)
+}
+
+func (attr Attribute) MinFloat() (float64, bool) {
+	return toFloat(attr.Min)
+}
+
+func (attr Attribute) MaxInt() (int, bool) {
+	return toInt(attr.Max)
+}
+
+func (attr Attribute) MaxFloat() (float64, bool) {
+	return toFloat(attr.Max)
+}
+
+// InStrings returns In as a string slice, it fails if any of the items isn't a string
+func (attr Attribute) InStrings() ([]string, bool) {
+	items, ok := toSlice(attr.In)
+	if !ok {
+		return nil, false
+	}
+
+	list := make([]string, len(items))
+	for i, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+
+		list[i] = str
+	}
+
+	return list, true
+}
+
+// InInts returns In as an int slice, it fails if any of the items isn't an integral number
+func (attr Attribute) InInts() ([]int, bool) {
+	items, ok := toSlice(attr.In)
+	if !ok {
+		return nil, false
+	}
+
+	list := make([]int, len(items))
+	for i, item := range items {
+		n, ok := toInt(item)
+		if !ok {
+			return nil, false
+		}
+
+		list[i] = n
+	}
+
+	return list, true
+}
+
+func toSlice(v any) ([]any, bool) {
+	if v == nil {
+		return nil, false
+	}
+
+	if items, ok := v.([]any); ok {
+		return items, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+
+	return items, true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	}
+
+	return 0, false
+}
+
+// toInt fails for the numbers out of the range of int, instead of wrapping them
+func toInt(v any) (int, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int64ToInt(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := rv.Uint(); u <= math.MaxInt {
+			return int(u), true
+		}
+
+		return 0, false
+	}
+
+	if n, ok := v.(json.Number); ok {
+		i, err := n.Int64()
+		if err != nil {
+			return 0, false
+		}
+
+		return int64ToInt(i)
+	}
+
+	// float64(math.MaxInt) rounds up to the first number out of the range
+	f, ok := toFloat(v)
+	if !ok || f != math.Trunc(f) || f < math.MinInt || f >= float64(math.MaxInt) {
+		return 0, false
+	}
+
+	return int(f), true
+}
+
+func int64ToInt(i int64) (int, bool) {
+	if i < math.MinInt || i > math.MaxInt {
+		return 0, false
+	}
+
+	return int(i), true
 }
 
 func (attr Attribute) toHashMap() map[string]interface{} {
@@ -51,6 +246,14 @@ func (attr Attribute) toHashMap() map[string]interface{} {
 		d["fields"] = attr.Fields
 	}
 
+	for k, v := range attr.Extra {
+		if attributeKeys[k] {
+			continue
+		}
+
+		d[k] = v
+	}
+
 	if len(d) == 0 {
 		return nil
 	}
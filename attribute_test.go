@@ -0,0 +1,135 @@
+package errors
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAttributeUnmarshalJSON(t *testing.T) {
+	var attr Attribute
+	if err := json.Unmarshal([]byte(`{"min": 1, "pattern": "^a$", "in": ["a", "b"], "unit": "cm", "scale": 2}`), &attr); err != nil {
+		t.Fatal(err)
+	}
+
+	if attr.Min != float64(1) || attr.Pattern == nil || *attr.Pattern != "^a$" {
+		t.Errorf("expected the known keys to be decoded into the fields, got %+v", attr)
+	}
+
+	want := map[string]any{"unit": "cm", "scale": float64(2)}
+	if !reflect.DeepEqual(attr.Extra, want) {
+		t.Errorf("expected the other keys in Extra, got %v", attr.Extra)
+	}
+
+	if _, ok := attr.GetExtra("min"); ok {
+		t.Errorf("expected the known keys to be left out of Extra")
+	}
+
+	if err := json.Unmarshal([]byte(`{"min": [`), &attr); err == nil {
+		t.Errorf("expected the invalid JSON to fail")
+	}
+}
+
+func TestAttributeExtraRoundTrip(t *testing.T) {
+	for _, version := range []DetailsVersion{DetailsV1, DetailsV2} {
+		f := NewFactory(WithDetailsVersion(version))
+		src := f.New(nil).AddReason("height", NewReason("range", nil, &Attribute{
+			Min:   1,
+			Max:   250,
+			Extra: map[string]any{"unit": "cm", "min": "ignored"},
+		}))
+
+		de := New(src.GRPCStatus().Err())
+
+		reasons := de.GetReasons()["height"]
+		if len(reasons) != 1 {
+			t.Fatalf("v%d: expected the reason to be received, got %v", version, de.GetReasons())
+		}
+
+		attr := reasons[0].(reason).Attribute
+		if attr == nil {
+			t.Fatalf("v%d: expected the attribute to be received", version)
+		}
+
+		if unit, ok := attr.GetExtra("unit"); !ok || unit != "cm" {
+			t.Errorf("v%d: expected the extra key to round trip, got %v", version, attr.Extra)
+		}
+
+		if _, ok := attr.GetExtra("min"); ok {
+			t.Errorf("v%d: expected the colliding key to be ignored, got %v", version, attr.Extra)
+		}
+
+		if min, ok := attr.MinInt(); !ok || min != 1 {
+			t.Errorf("v%d: expected the min to stay an int, got %v", version, attr.Min)
+		}
+
+		if max, ok := attr.MaxFloat(); !ok || max != 250 {
+			t.Errorf("v%d: expected the max as a float, got %v", version, attr.Max)
+		}
+	}
+}
+
+func TestAttributeGetters(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		int   int
+		ok    bool
+	}{
+		{"int", 3, 3, true},
+		{"int8", int8(-3), -3, true},
+		{"uint64", uint64(3), 3, true},
+		{"uint64 overflow", uint64(math.MaxUint64), 0, false},
+		{"integral float", float64(3), 3, true},
+		{"fractional float", 3.5, 0, false},
+		{"float overflow", 1e19, 0, false},
+		{"float underflow", -1e19, 0, false},
+		{"infinity", math.Inf(1), 0, false},
+		{"NaN", math.NaN(), 0, false},
+		{"json number", json.Number("3"), 3, true},
+		{"json number overflow", json.Number("99999999999999999999"), 0, false},
+		{"string", "3", 0, false},
+		{"nil", nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr := Attribute{Min: tt.value, Max: tt.value}
+
+			if n, ok := attr.MinInt(); n != tt.int || ok != tt.ok {
+				t.Errorf("MinInt: expected %d, %v, got %d, %v", tt.int, tt.ok, n, ok)
+			}
+
+			if n, ok := attr.MaxInt(); n != tt.int || ok != tt.ok {
+				t.Errorf("MaxInt: expected %d, %v, got %d, %v", tt.int, tt.ok, n, ok)
+			}
+		})
+	}
+
+	attr := Attribute{In: []any{"a", "b"}}
+	if list, ok := attr.InStrings(); !ok || !reflect.DeepEqual(list, []string{"a", "b"}) {
+		t.Errorf("expected the strings, got %v", list)
+	}
+
+	if _, ok := attr.InInts(); ok {
+		t.Errorf("expected the strings not to be ints")
+	}
+
+	attr = Attribute{In: []float64{1, 2}}
+	if list, ok := attr.InInts(); !ok || !reflect.DeepEqual(list, []int{1, 2}) {
+		t.Errorf("expected the integral floats as ints, got %v", list)
+	}
+
+	if _, ok := (Attribute{In: []any{1, 2.5}}).InInts(); ok {
+		t.Errorf("expected a fractional item to fail")
+	}
+
+	if _, ok := (Attribute{In: "a"}).InStrings(); ok {
+		t.Errorf("expected a scalar not to be a slice")
+	}
+
+	if f, ok := (Attribute{Min: json.Number("1.5")}).MinFloat(); !ok || f != 1.5 {
+		t.Errorf("expected the json number as a float, got %v", f)
+	}
+}
@@ -0,0 +1,350 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// BoundaryRule describes how an error is sanitized when it leaves the trusted network.
+// The non-public metadata and the internal details are always dropped.
+type BoundaryRule struct {
+	// Message replaces the message when it's not empty
+	Message          string
+	DropReasons      bool
+	DropMetadata     bool
+	DropInternalCode bool
+}
+
+type BoundaryPolicy struct {
+	// ClientErrors is applied to the codes lower than 500
+	ClientErrors BoundaryRule
+	// ServerErrors is applied to the codes greater than or equal to 500
+	ServerErrors BoundaryRule
+	// Rules overrides ClientErrors and ServerErrors for specific codes
+	Rules map[codes.Code]BoundaryRule
+	// StripHeaders holds the prefixes of the header and trailer keys removed at the boundary. e.g. "x-internal-"
+	StripHeaders []string
+}
+
+// DefaultBoundaryPolicy keeps the reasons of the client errors and
+// replaces the message, reasons and metadata of the server errors
+func DefaultBoundaryPolicy() *BoundaryPolicy {
+	return &BoundaryPolicy{
+		ServerErrors: BoundaryRule{
+			Message:      "internal server error",
+			DropReasons:  true,
+			DropMetadata: true,
+		},
+	}
+}
+
+func (p *BoundaryPolicy) rule(code codes.Code) BoundaryRule {
+	if r, ok := p.Rules[code]; ok {
+		return r
+	}
+
+	if code.HttpCode() >= http.StatusInternalServerError {
+		return p.ServerErrors
+	}
+
+	return p.ClientErrors
+}
+
+func (p *BoundaryPolicy) isStripped(key string) bool {
+	key = strings.ToLower(key)
+	for _, prefix := range p.StripHeaders {
+		if strings.HasPrefix(key, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *BoundaryPolicy) filterMD(md metadata.MD) metadata.MD {
+	filtered := make(metadata.MD, len(md))
+	for k, v := range md {
+		if !p.isStripped(k) {
+			filtered[k] = v
+		}
+	}
+
+	return filtered
+}
+
+// Sanitize rehydrates the error with `New` and returns a copy with the rule of its code applied.
+// The plain errors, which carry no status, are treated as 500 and keep the Unknown gRPC code.
+func (p *BoundaryPolicy) Sanitize(e error) DetailedError {
+	if e == nil {
+		return nil
+	}
+
+	src := New(e, CallerOffset(1))
+
+	var grpcCode *grpccodes.Code
+	if _, ok := status.FromError(e); !ok {
+		unknown := grpccodes.Unknown
+		grpcCode = &unknown
+		src.Code(codes.InternalServerError)
+	}
+
+	rule := p.rule(src.GetCode())
+
+	de := &err{
+		id:                  src.ID(),
+		message:             src.Error(),
+		original:            e,
+		frames:              src.StackFrames(),
+		headers:             p.filterMD(src.GetHeaders()),
+		trailers:            p.filterMD(src.GetTrailers()),
+		reasons:             make(map[string][]Reason),
+		reportable:          src.IsReportable(),
+		code:                src.GetCode(),
+		grpcCode:            grpcCode,
+		metadata:            make(map[string]interface{}),
+		visibility:          make(map[string]Visibility),
+		skipInternalDetails: true,
+		ctx:                 context.Background(),
+	}
+
+	if tc, ok := src.TraceContext(); ok {
+		de.trace = &tc
+	}
+
+	if d, ok := src.GetRetryAfter(); ok {
+		de.RetryAfter(d)
+	} else if src.IsRetryable() {
+		de.Retryable()
+	}
+
+	if rule.Message != "" {
+		de.message = rule.Message
+	}
+
+	if code, ok := src.GetInternalCode(); ok && !rule.DropInternalCode {
+		de.internalCode = &code
+	}
+
+	if !rule.DropReasons {
+		for _, fr := range src.GetOrderedReasons() {
+			de.appendReasons(fr.Key, fr.Reasons...)
+			if fr.FieldPath {
+				de.setPath(fr.Key, fr.Path)
+			}
+		}
+
+		de.globalReasons = append(de.globalReasons, src.GetGlobalReasons()...)
+	}
+
+	if !rule.DropMetadata {
+		for k, v := range src.GetMetadataFor(VisibilityPublic) {
+			de.AddMetadataWithVisibility(k, v, VisibilityPublic)
+		}
+	}
+
+	return de
+}
+
+type boundaryTransportStream struct {
+	grpc.ServerTransportStream
+	policy *BoundaryPolicy
+}
+
+func (s *boundaryTransportStream) SetHeader(md metadata.MD) error {
+	return s.ServerTransportStream.SetHeader(s.policy.filterMD(md))
+}
+
+func (s *boundaryTransportStream) SendHeader(md metadata.MD) error {
+	return s.ServerTransportStream.SendHeader(s.policy.filterMD(md))
+}
+
+func (s *boundaryTransportStream) SetTrailer(md metadata.MD) error {
+	return s.ServerTransportStream.SetTrailer(s.policy.filterMD(md))
+}
+
+// withTransportStream filters the headers and trailers set with grpc.SetHeader and grpc.SetTrailer
+func (p *BoundaryPolicy) withTransportStream(ctx context.Context) context.Context {
+	ts := grpc.ServerTransportStreamFromContext(ctx)
+	if ts == nil {
+		return ctx
+	}
+
+	return grpc.NewContextWithServerTransportStream(ctx, &boundaryTransportStream{
+		ServerTransportStream: ts,
+		policy:                p,
+	})
+}
+
+func (p *BoundaryPolicy) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(p.withTransportStream(ctx), req)
+		if err != nil {
+			return resp, p.Sanitize(err)
+		}
+
+		return resp, nil
+	}
+}
+
+type boundaryServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	policy *BoundaryPolicy
+}
+
+func (s *boundaryServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *boundaryServerStream) SetHeader(md metadata.MD) error {
+	return s.ServerStream.SetHeader(s.policy.filterMD(md))
+}
+
+func (s *boundaryServerStream) SendHeader(md metadata.MD) error {
+	return s.ServerStream.SendHeader(s.policy.filterMD(md))
+}
+
+func (s *boundaryServerStream) SetTrailer(md metadata.MD) {
+	s.ServerStream.SetTrailer(s.policy.filterMD(md))
+}
+
+func (p *BoundaryPolicy) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := &boundaryServerStream{
+			ServerStream: ss,
+			ctx:          p.withTransportStream(ss.Context()),
+			policy:       p,
+		}
+
+		if err := handler(srv, wrapped); err != nil {
+			return p.Sanitize(err)
+		}
+
+		return nil
+	}
+}
+
+// boundaryResponseWriter buffers the error responses, so their body can be sanitized
+type boundaryResponseWriter struct {
+	http.ResponseWriter
+	policy      *BoundaryPolicy
+	statusCode  int
+	wroteHeader bool
+	buffer      *bytes.Buffer
+}
+
+// HTTP gateways prefix the forwarded gRPC metadata, the prefixes are ignored while matching
+var httpMetadataPrefixes = []string{"grpc-metadata-", "grpc-trailer-"}
+
+func (w *boundaryResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.wroteHeader = true
+	w.statusCode = statusCode
+
+	header := w.Header()
+	for k := range header {
+		key := strings.ToLower(k)
+		for _, prefix := range httpMetadataPrefixes {
+			key = strings.TrimPrefix(key, prefix)
+		}
+
+		if w.policy.isStripped(key) {
+			header.Del(k)
+		}
+	}
+
+	if statusCode >= http.StatusBadRequest && strings.Contains(header.Get("Content-Type"), "json") {
+		w.buffer = &bytes.Buffer{}
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *boundaryResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffer != nil {
+		return w.buffer.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *boundaryResponseWriter) flush() {
+	if w.buffer == nil {
+		return
+	}
+
+	body := w.buffer.Bytes()
+
+	// the body of a client error is written as is, if it's not a JSON encoded google.rpc.Status.
+	// The server errors fail closed, e.g. a detail of a type unknown to the gateway can't be sanitized.
+	var sanitized DetailedError
+	pb := &spb.Status{}
+	if err := protojson.Unmarshal(body, pb); err == nil {
+		sanitized = w.policy.Sanitize(status.FromProto(pb).Err())
+	} else if w.statusCode >= http.StatusInternalServerError {
+		sanitized = w.policy.Sanitize(New(nil, Message(http.StatusText(w.statusCode)), ErrorCode(codes.Find(w.statusCode))))
+	}
+
+	if sanitized != nil {
+		// the unsanitized body is never written, even when the sanitized one can't be encoded
+		body, _ = protojson.Marshal(sanitized.GRPCStatus().Proto())
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	w.ResponseWriter.Write(body)
+}
+
+// Flush sends the buffered data of the successful responses, the streaming routes of the gateway require it.
+// The error responses are buffered until the handler returns.
+func (w *boundaryResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffer != nil {
+		return
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap allows http.ResponseController to reach the Hijacker and the other interfaces of the writer
+func (w *boundaryResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Middleware sanitizes the HTTP responses of a gRPC gateway, the stripped headers are removed
+// and the JSON encoded google.rpc.Status bodies of the error responses are sanitized
+func (p *BoundaryPolicy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bw := &boundaryResponseWriter{
+			ResponseWriter: w,
+			policy:         p,
+		}
+
+		next.ServeHTTP(bw, r)
+		bw.flush()
+	})
+}
@@ -0,0 +1,132 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestSanitizePlainErrors(t *testing.T) {
+	plain := stderrors.New("dial tcp 10.0.0.7:5432: connection refused")
+	de := DefaultBoundaryPolicy().Sanitize(plain)
+
+	if de.GetCode().HttpCode() != http.StatusInternalServerError {
+		t.Errorf("expected a plain error to be treated as 500, got %d", de.GetCode().HttpCode())
+	}
+
+	st := de.GRPCStatus()
+	if st.Code() != grpccodes.Unknown {
+		t.Errorf("expected the gRPC code to stay Unknown, got %s", st.Code())
+	}
+
+	if st.Message() != "internal server error" {
+		t.Errorf("expected the message of ServerErrors, got %q", st.Message())
+	}
+
+	if !stderrors.Is(de, plain) {
+		t.Errorf("expected the sanitized error to wrap the plain error")
+	}
+}
+
+func TestSanitizeStatusErrors(t *testing.T) {
+	src := New(nil, Message("name is required"), ErrorCode(codes.BadRequest)).
+		AddReason("name", SimpleReason("required"))
+	de := DefaultBoundaryPolicy().Sanitize(src.GRPCStatus().Err())
+
+	if de.GetCode() != codes.BadRequest || !de.HasReasons("name") {
+		t.Errorf("expected the code and the reasons of the client error, got %d %v", de.GetCode().HttpCode(), de.GetReasons())
+	}
+
+	st := de.GRPCStatus()
+	if st.Code() != codes.BadRequest.GrpcCode() || st.Message() != "name is required" {
+		t.Errorf("expected the client error to be kept, got %s %q", st.Code(), st.Message())
+	}
+}
+
+func serveThroughMiddleware(t *testing.T, policy *BoundaryPolicy, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	policy.Middleware(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/users/1", nil))
+
+	return recorder
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write([]byte(body))
+}
+
+func TestMiddleware(t *testing.T) {
+	policy := DefaultBoundaryPolicy()
+	policy.StripHeaders = []string{"x-internal-"}
+
+	t.Run("server error", func(t *testing.T) {
+		src := New(nil, Message("pq: relation users does not exist"), ErrorCode(codes.InternalServerError))
+		body, err := protojson.Marshal(src.GRPCStatus().Proto())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		recorder := serveThroughMiddleware(t, policy, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Grpc-Metadata-X-Internal-Host", "db-1")
+			writeJSON(w, http.StatusInternalServerError, string(body))
+		})
+
+		if recorder.Code != http.StatusInternalServerError || recorder.Header().Get("Grpc-Metadata-X-Internal-Host") != "" {
+			t.Errorf("expected the status code without the stripped header, got %d %v", recorder.Code, recorder.Header())
+		}
+
+		if strings.Contains(recorder.Body.String(), "pq:") || !strings.Contains(recorder.Body.String(), "internal server error") {
+			t.Errorf("expected the sanitized body, got %s", recorder.Body)
+		}
+	})
+
+	t.Run("undecodable server error", func(t *testing.T) {
+		recorder := serveThroughMiddleware(t, policy, func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, http.StatusInternalServerError, `{"code":13,"message":"pq: relation users does not exist",`+
+				`"details":[{"@type":"type.googleapis.com/unknown.Detail","sql":"select 1"}]}`)
+		})
+
+		if strings.Contains(recorder.Body.String(), "pq:") || !strings.Contains(recorder.Body.String(), "internal server error") {
+			t.Errorf("expected the body to be replaced, got %s", recorder.Body)
+		}
+	})
+
+	t.Run("undecodable client error", func(t *testing.T) {
+		recorder := serveThroughMiddleware(t, policy, func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, http.StatusNotFound, `{"error":"not found"}`)
+		})
+
+		if recorder.Body.String() != `{"error":"not found"}` {
+			t.Errorf("expected the client error to be kept, got %s", recorder.Body)
+		}
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		recorder := serveThroughMiddleware(t, policy, func(w http.ResponseWriter, _ *http.Request) {
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				t.Fatalf("expected the writer to be a http.Flusher")
+			}
+
+			w.Write([]byte(`{"result":1}`))
+			flusher.Flush()
+
+			if err := http.NewResponseController(w).Flush(); err != nil {
+				t.Errorf("expected the controller to reach the writer: %v", err)
+			}
+		})
+
+		if !recorder.Flushed || recorder.Body.String() != `{"result":1}` {
+			t.Errorf("expected the chunk to be flushed, got %v %s", recorder.Flushed, recorder.Body)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"context"
 	"encoding/json"
+	"sort"
 	"sync"
 
 	"github.com/poorly-written/grpc-http-response/codes"
@@ -32,28 +33,170 @@ func SetDefaultErrorCode(code codes.Code) {
 	})
 }
 
+var pathStyle = DottedPathStyle
+var pathStyleSetOnce sync.Once
+
+// SetPathStyle sets how FieldPath keys are rendered in reasons
+func SetPathStyle(style PathStyle) {
+	pathStyleSetOnce.Do(func() {
+		pathStyle = style
+	})
+}
+
+type DetailsVersion int
+
+const (
+	// DetailsV1 emits DetailedErrorResponse
+	DetailsV1 DetailsVersion = iota + 1
+	// DetailsV2 emits DetailedErrorResponseV2
+	DetailsV2
+	// DetailsV1AndV2 emits DetailedErrorResponseV2 followed by DetailedErrorResponse,
+	// so consumers that only understand v1 keep working during a rollout
+	DetailsV1AndV2
+)
+
+// detailsVersion keeps emitting v1 by default, so the consumers which only decode v1 keep their details.
+// The services opt into DetailsV2 once all their consumers understand it.
+var detailsVersion = DetailsV1AndV2
+var detailsVersionSetOnce sync.Once
+
+// SetDetailsVersion sets which detail message(s) the default error marshaler emits, it's ignored by a custom marshaler
+func SetDetailsVersion(version DetailsVersion) {
+	detailsVersionSetOnce.Do(func() {
+		detailsVersion = version
+	})
+}
+
 type ErrorDetails struct {
-	Message         *string
-	InternalCode    *string
-	Reasons         map[string][]Reason
+	// Version is set by the default unmarshaler to the version of the decoded message
+	Version      DetailsVersion
+	ID           string
+	TraceID      string
+	SpanID       string
+	Message      *string
+	InternalCode *string
+	Retryable    *bool
+	Reasons      map[string][]Reason
+	ReasonKeys   []string
+	// FieldPaths holds the paths of the Reasons keys rendered from a FieldPath
+	FieldPaths      map[string]FieldPath
+	GlobalReasons   []Reason
 	IncludeMetadata bool
 	Metadata        map[string]interface{}
+	// MetadataVisibility holds the visibility of the Metadata keys, only the public keys are marked on the wire
+	MetadataVisibility map[string]Visibility
+}
+
+func (d *ErrorDetails) publicMetadataKeys() []string {
+	keys := make([]string, 0)
+	for k := range d.Metadata {
+		if visibility, ok := d.MetadataVisibility[k]; ok && visibility == VisibilityPublic {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// setPublicMetadataKeys marks the received keys, the keys which aren't public are marked internal,
+// so they don't fall back to the default visibility of the receiver
+func (d *ErrorDetails) setPublicMetadataKeys(keys []string) {
+	d.MetadataVisibility = make(map[string]Visibility, len(d.Metadata))
+	for k := range d.Metadata {
+		d.MetadataVisibility[k] = VisibilityInternal
+	}
+
+	for _, k := range keys {
+		if _, ok := d.Metadata[k]; ok {
+			d.MetadataVisibility[k] = VisibilityPublic
+		}
+	}
+}
+
+// OrderedReasonKeys returns the keys of Reasons in the order of ReasonKeys.
+// Keys missing from ReasonKeys are appended in sorted order, so the result is always deterministic.
+func (d *ErrorDetails) OrderedReasonKeys() []string {
+	keys := make([]string, 0, len(d.Reasons))
+	seen := make(map[string]bool, len(d.Reasons))
+
+	for _, key := range d.ReasonKeys {
+		if _, ok := d.Reasons[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	rest := make([]string, 0, len(d.Reasons)-len(keys))
+	for key := range d.Reasons {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+func unmarshalReasons(bytes []byte) ([]Reason, error) {
+	var items []reason
+	if err := json.Unmarshal(bytes, &items); err != nil {
+		return nil, err
+	}
+
+	list := make([]Reason, len(items))
+	for i, item := range items {
+		list[i] = item
+	}
+
+	return list, nil
+}
+
+func reasonsToList(items []Reason) ([]interface{}, error) {
+	list := make([]interface{}, len(items))
+	for i, each := range items {
+		d, err := normalizeValue(each.ToHashMap())
+		if err != nil {
+			return nil, err
+		}
+
+		list[i] = d
+	}
+
+	return list, nil
 }
 
 type errorUnmarshalerFunc func(idx int, err any) (*ErrorDetails, error)
 
+// errorUnmarshaler accepts both DetailedErrorResponse and DetailedErrorResponseV2
 var errorUnmarshaler errorUnmarshalerFunc = func(_ int, err any) (*ErrorDetails, error) {
 	anyErr, ok := err.(*anypb.Any)
 	if !ok {
 		return nil, nil
 	}
 
+	if anyErr.MessageIs(&DetailedErrorResponseV2{}) {
+		return unmarshalDetailsV2(anyErr)
+	}
+
+	return unmarshalDetailsV1(anyErr)
+}
+
+func unmarshalDetailsV1(anyErr *anypb.Any) (*ErrorDetails, error) {
 	dErr := &DetailedErrorResponse{}
 	if err := anyErr.UnmarshalTo(dErr); err != nil {
 		return nil, err
 	}
 
-	var details = &ErrorDetails{}
+	var details = &ErrorDetails{
+		Version:   DetailsV1,
+		ID:        dErr.GetId(),
+		TraceID:   dErr.GetTraceId(),
+		SpanID:    dErr.GetSpanId(),
+		Retryable: dErr.Retryable,
+	}
 
 	if dErr.Message != "" {
 		details.Message = &dErr.Message
@@ -75,6 +218,7 @@ var errorUnmarshaler errorUnmarshalerFunc = func(_ int, err any) (*ErrorDetails,
 		}
 
 		details.Metadata = metadata
+		details.setPublicMetadataKeys(dErr.PublicMetadataKeys)
 	}
 
 	if dErr.Reasons != nil {
@@ -97,6 +241,22 @@ var errorUnmarshaler errorUnmarshalerFunc = func(_ int, err any) (*ErrorDetails,
 		}
 
 		details.Reasons = list
+		details.ReasonKeys = dErr.ReasonKeys
+		details.FieldPaths = pathsFromProto(dErr.FieldPaths)
+	}
+
+	if dErr.GlobalReasons != nil {
+		bytes, err := dErr.GlobalReasons.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		list, err := unmarshalReasons(bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		details.GlobalReasons = list
 	}
 
 	return details, nil
@@ -112,12 +272,62 @@ func SetErrorUnmarshaler(unmarshaler errorUnmarshalerFunc) {
 
 type errorMarshalerFunc func(*ErrorDetails) (*anypb.Any, error)
 
-var errorMarshaler errorMarshalerFunc = func(details *ErrorDetails) (*anypb.Any, error) {
+// errorMarshaler is nil unless it's set with SetErrorMarshaler, the default marshaler is used then
+var errorMarshaler errorMarshalerFunc
+
+// marshalDetails emits the single detail of the custom marshaler, otherwise
+// the default marshaler emits the detail message(s) of the version
+func marshalDetails(marshaler errorMarshalerFunc, version DetailsVersion, details *ErrorDetails) ([]*anypb.Any, error) {
+	if marshaler != nil {
+		detail, err := marshaler(details)
+		if err != nil || detail == nil {
+			return nil, err
+		}
+
+		return []*anypb.Any{detail}, nil
+	}
+
+	var marshalers []errorMarshalerFunc
+	switch version {
+	case DetailsV1:
+		marshalers = []errorMarshalerFunc{marshalDetailsV1}
+	case DetailsV2:
+		marshalers = []errorMarshalerFunc{marshalDetailsV2}
+	default:
+		// v2 goes first, so the consumers taking the first detail get the richer message
+		marshalers = []errorMarshalerFunc{marshalDetailsV2, marshalDetailsV1}
+	}
+
+	list := make([]*anypb.Any, 0, len(marshalers))
+	for _, marshal := range marshalers {
+		detail, err := marshal(details)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, detail)
+	}
+
+	return list, nil
+}
+
+func marshalDetailsV1(details *ErrorDetails) (*anypb.Any, error) {
 	// [Concept] https://stackoverflow.com/a/75720585/2190689
 	de := &DetailedErrorResponse{
 		Error: true,
 	}
 
+	if details.ID != "" {
+		de.Id = &details.ID
+	}
+
+	if details.TraceID != "" {
+		de.TraceId = &details.TraceID
+		de.SpanId = &details.SpanID
+	}
+
+	de.Retryable = details.Retryable
+
 	if details.Message != nil {
 		de.Message = *details.Message
 	}
@@ -129,9 +339,9 @@ var errorMarshaler errorMarshalerFunc = func(details *ErrorDetails) (*anypb.Any,
 	if reasons := details.Reasons; len(reasons) > 0 {
 		reasonsMap := make(map[string]interface{})
 		for key, items := range reasons {
-			list := make([]interface{}, len(items))
-			for i, each := range items {
-				list[i] = each.ToHashMap()
+			list, err := reasonsToList(items)
+			if err != nil {
+				return nil, err
 			}
 
 			reasonsMap[key] = list
@@ -143,15 +353,38 @@ var errorMarshaler errorMarshalerFunc = func(details *ErrorDetails) (*anypb.Any,
 		}
 
 		de.Reasons = reasonPb
+		// structpb.Struct is a map, the order of the keys is sent separately
+		de.ReasonKeys = details.OrderedReasonKeys()
+		de.FieldPaths = pathsToProto(details.FieldPaths)
+	}
+
+	if len(details.GlobalReasons) > 0 {
+		list, err := reasonsToList(details.GlobalReasons)
+		if err != nil {
+			return nil, err
+		}
+
+		globalPb, err := structpb.NewList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		de.GlobalReasons = globalPb
 	}
 
 	if details.IncludeMetadata && len(details.Metadata) > 0 {
-		mdPb, err := structpb.NewStruct(details.Metadata)
+		md, err := normalizeMetadata(details.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		mdPb, err := structpb.NewStruct(md)
 		if err != nil {
 			return nil, err
 		}
 
 		de.Metadata = mdPb
+		de.PublicMetadataKeys = details.publicMetadataKeys()
 	}
 
 	return anypb.New(de)
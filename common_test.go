@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestDefaultDetailsKeepV1Consumers(t *testing.T) {
+	st := New(nil, Message("invalid"), InternalCode("E1")).GRPCStatus()
+
+	var v1 *DetailedErrorResponse
+	for _, detail := range st.Details() {
+		anyErr, ok := detail.(*anypb.Any)
+		if !ok {
+			continue
+		}
+
+		candidate := &DetailedErrorResponse{}
+		if anyErr.UnmarshalTo(candidate) == nil {
+			v1 = candidate
+		}
+	}
+
+	if v1 == nil {
+		t.Fatalf("expected a DetailedErrorResponse among %d details", len(st.Details()))
+	}
+
+	if v1.GetCode() != "E1" {
+		t.Errorf("expected the internal code E1, got %q", v1.GetCode())
+	}
+}
+
+func TestMarshalDetails(t *testing.T) {
+	details := &ErrorDetails{}
+	custom := func(*ErrorDetails) (*anypb.Any, error) {
+		return anypb.New(&DetailedErrorResponse{Error: true})
+	}
+
+	tests := []struct {
+		name      string
+		marshaler errorMarshalerFunc
+		version   DetailsVersion
+		want      int
+	}{
+		{name: "v1", version: DetailsV1, want: 1},
+		{name: "v2", version: DetailsV2, want: 1},
+		{name: "v1 and v2", version: DetailsV1AndV2, want: 2},
+		{name: "custom marshaler ignores the version", marshaler: custom, version: DetailsV1AndV2, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := marshalDetails(tt.marshaler, tt.version, details)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(list) != tt.want {
+				t.Errorf("expected %d details, got %d", tt.want, len(list))
+			}
+		})
+	}
+}
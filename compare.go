@@ -0,0 +1,243 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type compareConfig struct {
+	ignoreFrames  bool
+	ignoreMessage bool
+	ignoredKeys   map[string]bool
+}
+
+type CompareOption func(*compareConfig)
+
+func IgnoreFrames() CompareOption {
+	return func(c *compareConfig) {
+		c.ignoreFrames = true
+	}
+}
+
+func IgnoreMessage() CompareOption {
+	return func(c *compareConfig) {
+		c.ignoreMessage = true
+	}
+}
+
+func IgnoreMetadataKeys(keys ...string) CompareOption {
+	return func(c *compareConfig) {
+		for _, key := range keys {
+			c.ignoredKeys[key] = true
+		}
+	}
+}
+
+// Equal compares the message, code, internal code, retryability, reasons, metadata and frames of the errors.
+// The IDs, trace contexts, headers and trailers aren't compared. The other errors are rehydrated with `New`.
+func Equal(a, b error, opts ...CompareOption) bool {
+	return Diff(a, b, opts...) == ""
+}
+
+// Diff returns a line for every field which differs, it's empty if the errors are equal.
+// The values are compared after normalizing them, only the numeric kinds are coerced, so an int and an int64
+// holding 1 are equal like they are after a `GRPCStatus`/`New` round-trip, but "1" and 1 aren't.
+//
+//	code: 400 != 500
+//	metadata[user_id]: 1 != <missing>
+func Diff(a, b error, opts ...CompareOption) string {
+	c := &compareConfig{
+		ignoredKeys: make(map[string]bool),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return ""
+		}
+
+		return fmt.Sprintf("error: %v != %v", a, b)
+	}
+
+	return c.diff(New(a, CallerOffset(1)), New(b, CallerOffset(1)))
+}
+
+const missingValue = "<missing>"
+
+func (c *compareConfig) diff(a, b DetailedError) string {
+	var lines []string
+	field := func(name string, x, y string) {
+		if x != y {
+			lines = append(lines, fmt.Sprintf("%s: %s != %s", name, x, y))
+		}
+	}
+
+	value := func(name string, x, y interface{}) {
+		if !reflect.DeepEqual(x, y) {
+			lines = append(lines, fmt.Sprintf("%s: %s != %s", name, valueString(x), valueString(y)))
+		}
+	}
+
+	if !c.ignoreMessage {
+		field("message", fmt.Sprintf("%q", a.Error()), fmt.Sprintf("%q", b.Error()))
+	}
+
+	field("code", fmt.Sprintf("%d", a.GetCode().HttpCode()), fmt.Sprintf("%d", b.GetCode().HttpCode()))
+	field("internal code", internalCodeOf(a), internalCodeOf(b))
+	field("retryable", fmt.Sprintf("%t", a.IsRetryable()), fmt.Sprintf("%t", b.IsRetryable()))
+	field("retry after", retryAfterOf(a), retryAfterOf(b))
+	value("global reasons", reasonsOf(a.GetGlobalReasons()), reasonsOf(b.GetGlobalReasons()))
+
+	aReasons, bReasons := a.GetReasons(), b.GetReasons()
+	for _, key := range unionKeys(aReasons, bReasons) {
+		value(fmt.Sprintf("reasons[%s]", key), keyedReasonsOf(aReasons, key), keyedReasonsOf(bReasons, key))
+	}
+
+	aMD, bMD := a.GetMetadata(), b.GetMetadata()
+	for _, key := range unionKeys(aMD, bMD) {
+		if !c.ignoredKeys[key] {
+			value(fmt.Sprintf("metadata[%s]", key), metadataOf(aMD, key), metadataOf(bMD, key))
+		}
+	}
+
+	if !c.ignoreFrames {
+		aFrames, bFrames := a.StackTrace(), b.StackTrace()
+		for i := 0; i < len(aFrames) || i < len(bFrames); i++ {
+			field(fmt.Sprintf("frames[%d]", i), frameOf(aFrames, i), frameOf(bFrames, i))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func internalCodeOf(de DetailedError) string {
+	if code, ok := de.GetInternalCode(); ok {
+		return fmt.Sprintf("%q", code)
+	}
+
+	return missingValue
+}
+
+func retryAfterOf(de DetailedError) string {
+	if d, ok := de.GetRetryAfter(); ok {
+		return d.String()
+	}
+
+	return missingValue
+}
+
+// missing marks the keys found in only one of the errors
+type missing struct{}
+
+func reasonsOf(reasons []Reason) interface{} {
+	list := make([]interface{}, len(reasons))
+	for i, r := range reasons {
+		list[i] = r.ToHashMap()
+	}
+
+	return comparableValue(list)
+}
+
+func keyedReasonsOf(reasons map[string][]Reason, key string) interface{} {
+	list, ok := reasons[key]
+	if !ok {
+		return missing{}
+	}
+
+	return reasonsOf(list)
+}
+
+func metadataOf(md map[string]interface{}, key string) interface{} {
+	value, ok := md[key]
+	if !ok {
+		return missing{}
+	}
+
+	return comparableValue(value)
+}
+
+func frameOf(frames []info, i int) string {
+	if i >= len(frames) {
+		return missingValue
+	}
+
+	return fmt.Sprintf("%s.%s %s:%d", frames[i].Package, frames[i].Name, frames[i].File, frames[i].Line)
+}
+
+// comparableValue normalizes v and converts its numbers into float64, the other kinds are kept as is
+func comparableValue(v interface{}) interface{} {
+	if normalized, err := normalizeValue(v); err == nil {
+		v = normalized
+	}
+
+	return coerceNumbers(v)
+}
+
+func coerceNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+
+		return v.String()
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = coerceNumbers(item)
+		}
+
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			m[k] = coerceNumbers(item)
+		}
+
+		return m
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+
+	return v
+}
+
+// valueString quotes the strings, so "1" and 1 are told apart in the diff
+func valueString(v interface{}) string {
+	if _, ok := v.(missing); ok {
+		return missingValue
+	}
+
+	return fmt.Sprintf("%#v", v)
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"testing"
+)
+
+func TestEqualCoercesOnlyNumbers(t *testing.T) {
+	withMetadata := func(value interface{}) DetailedError {
+		return New(nil, Message("failed")).AddMetadata("value", value)
+	}
+
+	cases := []struct {
+		name  string
+		a, b  interface{}
+		equal bool
+	}{
+		{"int and int64", 1, int64(1), true},
+		{"int and float64", 1, float64(1), true},
+		{"uint8 and int32", uint8(7), int32(7), true},
+		{"nested numbers", map[string]interface{}{"n": []int{1}}, map[string]interface{}{"n": []float64{1}}, true},
+		{"string and int", "1", 1, false},
+		{"string and bool", "true", true, false},
+		{"nil and string", nil, "<nil>", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Equal(withMetadata(tc.a), withMetadata(tc.b), IgnoreFrames()); got != tc.equal {
+				t.Errorf("Equal(%#v, %#v) = %v, expected %v: %s", tc.a, tc.b, got, tc.equal, Diff(withMetadata(tc.a), withMetadata(tc.b), IgnoreFrames()))
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := New(nil, Message("failed")).IncludeMetadata().AddMetadata("user_id", "1").AddReason("name", SimpleReason("required"))
+	b := New(nil, Message("failed")).AddMetadata("user_id", 1)
+
+	want := "reasons[name]: []interface {}{map[string]interface {}{\"type\":\"required\"}} != <missing>\n" +
+		"metadata[user_id]: \"1\" != 1"
+	if got := Diff(a, b, IgnoreFrames()); got != want {
+		t.Errorf("unexpected diff\n--- want\n%s\n--- got\n%s", want, got)
+	}
+
+	if !Equal(a, roundTrip(a), IgnoreFrames()) {
+		t.Errorf("expected the error to equal itself after a round-trip: %s", Diff(a, roundTrip(a), IgnoreFrames()))
+	}
+}
+
+// roundTrip mirrors errorstest.RoundTrip, which can't be imported by the tests of this package
+func roundTrip(de DetailedError) DetailedError {
+	return New(de.GRPCStatus().Err())
+}
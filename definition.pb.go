@@ -21,35 +21,996 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type InternalDetails_Protection int32
+
+const (
+	InternalDetails_PROTECTION_SIGNED    InternalDetails_Protection = 0
+	InternalDetails_PROTECTION_ENCRYPTED InternalDetails_Protection = 1
+)
+
+// Enum value maps for InternalDetails_Protection.
+var (
+	InternalDetails_Protection_name = map[int32]string{
+		0: "PROTECTION_SIGNED",
+		1: "PROTECTION_ENCRYPTED",
+	}
+	InternalDetails_Protection_value = map[string]int32{
+		"PROTECTION_SIGNED":    0,
+		"PROTECTION_ENCRYPTED": 1,
+	}
+)
+
+func (x InternalDetails_Protection) Enum() *InternalDetails_Protection {
+	p := new(InternalDetails_Protection)
+	*p = x
+	return p
+}
+
+func (x InternalDetails_Protection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InternalDetails_Protection) Descriptor() protoreflect.EnumDescriptor {
+	return file_definition_proto_enumTypes[0].Descriptor()
+}
+
+func (InternalDetails_Protection) Type() protoreflect.EnumType {
+	return &file_definition_proto_enumTypes[0]
+}
+
+func (x InternalDetails_Protection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InternalDetails_Protection.Descriptor instead.
+func (InternalDetails_Protection) EnumDescriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{3, 0}
+}
+
+// ReasonPath is the structured field path a reason key was rendered from,
+// so the receivers render it in their own style without parsing the key
+type ReasonPath struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Segments []*ReasonPath_Segment `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty"`
+}
+
+func (x *ReasonPath) Reset() {
+	*x = ReasonPath{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReasonPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReasonPath) ProtoMessage() {}
+
+func (x *ReasonPath) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReasonPath.ProtoReflect.Descriptor instead.
+func (*ReasonPath) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReasonPath) GetSegments() []*ReasonPath_Segment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
 type DetailedErrorResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Error    bool             `protobuf:"varint,1,opt,name=error,proto3" json:"error,omitempty"`
-	Message  string           `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Reasons  *structpb.Struct `protobuf:"bytes,3,opt,name=reasons,proto3,oneof" json:"reasons,omitempty"`
-	Metadata *structpb.Struct `protobuf:"bytes,4,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
-	Code     *string          `protobuf:"bytes,5,opt,name=code,proto3,oneof" json:"code,omitempty"`
+	Error              bool                `protobuf:"varint,1,opt,name=error,proto3" json:"error,omitempty"`
+	Message            string              `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reasons            *structpb.Struct    `protobuf:"bytes,3,opt,name=reasons,proto3,oneof" json:"reasons,omitempty"`
+	Metadata           *structpb.Struct    `protobuf:"bytes,4,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	Code               *string             `protobuf:"bytes,5,opt,name=code,proto3,oneof" json:"code,omitempty"`
+	ReasonKeys         []string            `protobuf:"bytes,6,rep,name=reason_keys,json=reasonKeys,proto3" json:"reason_keys,omitempty"`
+	GlobalReasons      *structpb.ListValue `protobuf:"bytes,7,opt,name=global_reasons,json=globalReasons,proto3,oneof" json:"global_reasons,omitempty"`
+	PublicMetadataKeys []string            `protobuf:"bytes,8,rep,name=public_metadata_keys,json=publicMetadataKeys,proto3" json:"public_metadata_keys,omitempty"`
+	Id                 *string             `protobuf:"bytes,9,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	TraceId            *string             `protobuf:"bytes,10,opt,name=trace_id,json=traceId,proto3,oneof" json:"trace_id,omitempty"`
+	SpanId             *string             `protobuf:"bytes,11,opt,name=span_id,json=spanId,proto3,oneof" json:"span_id,omitempty"`
+	Retryable          *bool               `protobuf:"varint,12,opt,name=retryable,proto3,oneof" json:"retryable,omitempty"`
+	// the paths of the reason keys rendered from field paths, the other keys are free-form
+	FieldPaths map[string]*ReasonPath `protobuf:"bytes,14,rep,name=field_paths,json=fieldPaths,proto3" json:"field_paths,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DetailedErrorResponse) Reset() {
+	*x = DetailedErrorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailedErrorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedErrorResponse) ProtoMessage() {}
+
+func (x *DetailedErrorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedErrorResponse.ProtoReflect.Descriptor instead.
+func (*DetailedErrorResponse) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DetailedErrorResponse) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+func (x *DetailedErrorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponse) GetReasons() *structpb.Struct {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponse) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponse) GetCode() string {
+	if x != nil && x.Code != nil {
+		return *x.Code
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponse) GetReasonKeys() []string {
+	if x != nil {
+		return x.ReasonKeys
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponse) GetGlobalReasons() *structpb.ListValue {
+	if x != nil {
+		return x.GlobalReasons
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponse) GetPublicMetadataKeys() []string {
+	if x != nil {
+		return x.PublicMetadataKeys
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponse) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponse) GetTraceId() string {
+	if x != nil && x.TraceId != nil {
+		return *x.TraceId
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponse) GetSpanId() string {
+	if x != nil && x.SpanId != nil {
+		return *x.SpanId
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponse) GetRetryable() bool {
+	if x != nil && x.Retryable != nil {
+		return *x.Retryable
+	}
+	return false
+}
+
+func (x *DetailedErrorResponse) GetFieldPaths() map[string]*ReasonPath {
+	if x != nil {
+		return x.FieldPaths
+	}
+	return nil
+}
+
+// DetailedErrorResponseV2 is the strongly-typed version of DetailedErrorResponse.
+// Numbers keep their type and reasons keep their order.
+type DetailedErrorResponseV2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Error              bool                                      `protobuf:"varint,1,opt,name=error,proto3" json:"error,omitempty"`
+	Message            string                                    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Code               *string                                   `protobuf:"bytes,3,opt,name=code,proto3,oneof" json:"code,omitempty"`
+	Reasons            []*DetailedErrorResponseV2_FieldReasons   `protobuf:"bytes,4,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	GlobalReasons      []*DetailedErrorResponseV2_Reason         `protobuf:"bytes,5,rep,name=global_reasons,json=globalReasons,proto3" json:"global_reasons,omitempty"`
+	Metadata           map[string]*DetailedErrorResponseV2_Value `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PublicMetadataKeys []string                                  `protobuf:"bytes,7,rep,name=public_metadata_keys,json=publicMetadataKeys,proto3" json:"public_metadata_keys,omitempty"`
+	Id                 *string                                   `protobuf:"bytes,8,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	TraceId            *string                                   `protobuf:"bytes,9,opt,name=trace_id,json=traceId,proto3,oneof" json:"trace_id,omitempty"`
+	SpanId             *string                                   `protobuf:"bytes,10,opt,name=span_id,json=spanId,proto3,oneof" json:"span_id,omitempty"`
+	Retryable          *bool                                     `protobuf:"varint,11,opt,name=retryable,proto3,oneof" json:"retryable,omitempty"`
+}
+
+func (x *DetailedErrorResponseV2) Reset() {
+	*x = DetailedErrorResponseV2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailedErrorResponseV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedErrorResponseV2) ProtoMessage() {}
+
+func (x *DetailedErrorResponseV2) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedErrorResponseV2.ProtoReflect.Descriptor instead.
+func (*DetailedErrorResponseV2) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DetailedErrorResponseV2) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+func (x *DetailedErrorResponseV2) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2) GetCode() string {
+	if x != nil && x.Code != nil {
+		return *x.Code
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2) GetReasons() []*DetailedErrorResponseV2_FieldReasons {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2) GetGlobalReasons() []*DetailedErrorResponseV2_Reason {
+	if x != nil {
+		return x.GlobalReasons
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2) GetMetadata() map[string]*DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2) GetPublicMetadataKeys() []string {
+	if x != nil {
+		return x.PublicMetadataKeys
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2) GetTraceId() string {
+	if x != nil && x.TraceId != nil {
+		return *x.TraceId
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2) GetSpanId() string {
+	if x != nil && x.SpanId != nil {
+		return *x.SpanId
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2) GetRetryable() bool {
+	if x != nil && x.Retryable != nil {
+		return *x.Retryable
+	}
+	return false
+}
+
+// InternalDetails carries the internal-only details between trusted services.
+// It's signed or encrypted with a shared key and must be stripped at the trust boundaries.
+type InternalDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Protection InternalDetails_Protection `protobuf:"varint,1,opt,name=protection,proto3,enum=errors.InternalDetails_Protection" json:"protection,omitempty"`
+	KeyId      string                     `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// serialized InternalPayload, encrypted when the protection is PROTECTION_ENCRYPTED
+	Payload   []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Nonce     []byte `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *InternalDetails) Reset() {
+	*x = InternalDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InternalDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalDetails) ProtoMessage() {}
+
+func (x *InternalDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalDetails.ProtoReflect.Descriptor instead.
+func (*InternalDetails) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InternalDetails) GetProtection() InternalDetails_Protection {
+	if x != nil {
+		return x.Protection
+	}
+	return InternalDetails_PROTECTION_SIGNED
+}
+
+func (x *InternalDetails) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *InternalDetails) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *InternalDetails) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *InternalDetails) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+type InternalPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata map[string]*DetailedErrorResponseV2_Value `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Frames   []*InternalPayload_Frame                  `protobuf:"bytes,2,rep,name=frames,proto3" json:"frames,omitempty"`
+}
+
+func (x *InternalPayload) Reset() {
+	*x = InternalPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InternalPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalPayload) ProtoMessage() {}
+
+func (x *InternalPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalPayload.ProtoReflect.Descriptor instead.
+func (*InternalPayload) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InternalPayload) GetMetadata() map[string]*DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *InternalPayload) GetFrames() []*InternalPayload_Frame {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+type ReasonPath_Segment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Kind:
+	//	*ReasonPath_Segment_Key
+	//	*ReasonPath_Segment_Index
+	Kind isReasonPath_Segment_Kind `protobuf_oneof:"kind"`
+}
+
+func (x *ReasonPath_Segment) Reset() {
+	*x = ReasonPath_Segment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReasonPath_Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReasonPath_Segment) ProtoMessage() {}
+
+func (x *ReasonPath_Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReasonPath_Segment.ProtoReflect.Descriptor instead.
+func (*ReasonPath_Segment) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (m *ReasonPath_Segment) GetKind() isReasonPath_Segment_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *ReasonPath_Segment) GetKey() string {
+	if x, ok := x.GetKind().(*ReasonPath_Segment_Key); ok {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ReasonPath_Segment) GetIndex() int64 {
+	if x, ok := x.GetKind().(*ReasonPath_Segment_Index); ok {
+		return x.Index
+	}
+	return 0
+}
+
+type isReasonPath_Segment_Kind interface {
+	isReasonPath_Segment_Kind()
+}
+
+type ReasonPath_Segment_Key struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3,oneof"`
+}
+
+type ReasonPath_Segment_Index struct {
+	Index int64 `protobuf:"varint,2,opt,name=index,proto3,oneof"`
+}
+
+func (*ReasonPath_Segment_Key) isReasonPath_Segment_Kind() {}
+
+func (*ReasonPath_Segment_Index) isReasonPath_Segment_Kind() {}
+
+type DetailedErrorResponseV2_Value struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Kind:
+	//	*DetailedErrorResponseV2_Value_NullValue
+	//	*DetailedErrorResponseV2_Value_BoolValue
+	//	*DetailedErrorResponseV2_Value_IntValue
+	//	*DetailedErrorResponseV2_Value_UintValue
+	//	*DetailedErrorResponseV2_Value_DoubleValue
+	//	*DetailedErrorResponseV2_Value_StringValue
+	//	*DetailedErrorResponseV2_Value_ListValue
+	//	*DetailedErrorResponseV2_Value_MapValue
+	Kind isDetailedErrorResponseV2_Value_Kind `protobuf_oneof:"kind"`
+}
+
+func (x *DetailedErrorResponseV2_Value) Reset() {
+	*x = DetailedErrorResponseV2_Value{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailedErrorResponseV2_Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedErrorResponseV2_Value) ProtoMessage() {}
+
+func (x *DetailedErrorResponseV2_Value) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedErrorResponseV2_Value.ProtoReflect.Descriptor instead.
+func (*DetailedErrorResponseV2_Value) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (m *DetailedErrorResponseV2_Value) GetKind() isDetailedErrorResponseV2_Value_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Value) GetNullValue() structpb.NullValue {
+	if x, ok := x.GetKind().(*DetailedErrorResponseV2_Value_NullValue); ok {
+		return x.NullValue
+	}
+	return structpb.NullValue(0)
+}
+
+func (x *DetailedErrorResponseV2_Value) GetBoolValue() bool {
+	if x, ok := x.GetKind().(*DetailedErrorResponseV2_Value_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (x *DetailedErrorResponseV2_Value) GetIntValue() int64 {
+	if x, ok := x.GetKind().(*DetailedErrorResponseV2_Value_IntValue); ok {
+		return x.IntValue
+	}
+	return 0
+}
+
+func (x *DetailedErrorResponseV2_Value) GetUintValue() uint64 {
+	if x, ok := x.GetKind().(*DetailedErrorResponseV2_Value_UintValue); ok {
+		return x.UintValue
+	}
+	return 0
+}
+
+func (x *DetailedErrorResponseV2_Value) GetDoubleValue() float64 {
+	if x, ok := x.GetKind().(*DetailedErrorResponseV2_Value_DoubleValue); ok {
+		return x.DoubleValue
+	}
+	return 0
+}
+
+func (x *DetailedErrorResponseV2_Value) GetStringValue() string {
+	if x, ok := x.GetKind().(*DetailedErrorResponseV2_Value_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2_Value) GetListValue() *DetailedErrorResponseV2_ListValue {
+	if x, ok := x.GetKind().(*DetailedErrorResponseV2_Value_ListValue); ok {
+		return x.ListValue
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Value) GetMapValue() *DetailedErrorResponseV2_MapValue {
+	if x, ok := x.GetKind().(*DetailedErrorResponseV2_Value_MapValue); ok {
+		return x.MapValue
+	}
+	return nil
+}
+
+type isDetailedErrorResponseV2_Value_Kind interface {
+	isDetailedErrorResponseV2_Value_Kind()
+}
+
+type DetailedErrorResponseV2_Value_NullValue struct {
+	NullValue structpb.NullValue `protobuf:"varint,1,opt,name=null_value,json=nullValue,proto3,enum=google.protobuf.NullValue,oneof"`
+}
+
+type DetailedErrorResponseV2_Value_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type DetailedErrorResponseV2_Value_IntValue struct {
+	IntValue int64 `protobuf:"zigzag64,3,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type DetailedErrorResponseV2_Value_UintValue struct {
+	UintValue uint64 `protobuf:"varint,4,opt,name=uint_value,json=uintValue,proto3,oneof"`
+}
+
+type DetailedErrorResponseV2_Value_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,5,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type DetailedErrorResponseV2_Value_StringValue struct {
+	StringValue string `protobuf:"bytes,6,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type DetailedErrorResponseV2_Value_ListValue struct {
+	ListValue *DetailedErrorResponseV2_ListValue `protobuf:"bytes,7,opt,name=list_value,json=listValue,proto3,oneof"`
+}
+
+type DetailedErrorResponseV2_Value_MapValue struct {
+	MapValue *DetailedErrorResponseV2_MapValue `protobuf:"bytes,8,opt,name=map_value,json=mapValue,proto3,oneof"`
+}
+
+func (*DetailedErrorResponseV2_Value_NullValue) isDetailedErrorResponseV2_Value_Kind() {}
+
+func (*DetailedErrorResponseV2_Value_BoolValue) isDetailedErrorResponseV2_Value_Kind() {}
+
+func (*DetailedErrorResponseV2_Value_IntValue) isDetailedErrorResponseV2_Value_Kind() {}
+
+func (*DetailedErrorResponseV2_Value_UintValue) isDetailedErrorResponseV2_Value_Kind() {}
+
+func (*DetailedErrorResponseV2_Value_DoubleValue) isDetailedErrorResponseV2_Value_Kind() {}
+
+func (*DetailedErrorResponseV2_Value_StringValue) isDetailedErrorResponseV2_Value_Kind() {}
+
+func (*DetailedErrorResponseV2_Value_ListValue) isDetailedErrorResponseV2_Value_Kind() {}
+
+func (*DetailedErrorResponseV2_Value_MapValue) isDetailedErrorResponseV2_Value_Kind() {}
+
+type DetailedErrorResponseV2_ListValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []*DetailedErrorResponseV2_Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *DetailedErrorResponseV2_ListValue) Reset() {
+	*x = DetailedErrorResponseV2_ListValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailedErrorResponseV2_ListValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedErrorResponseV2_ListValue) ProtoMessage() {}
+
+func (x *DetailedErrorResponseV2_ListValue) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedErrorResponseV2_ListValue.ProtoReflect.Descriptor instead.
+func (*DetailedErrorResponseV2_ListValue) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *DetailedErrorResponseV2_ListValue) GetValues() []*DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type DetailedErrorResponseV2_MapValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields map[string]*DetailedErrorResponseV2_Value `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DetailedErrorResponseV2_MapValue) Reset() {
+	*x = DetailedErrorResponseV2_MapValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailedErrorResponseV2_MapValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedErrorResponseV2_MapValue) ProtoMessage() {}
+
+func (x *DetailedErrorResponseV2_MapValue) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedErrorResponseV2_MapValue.ProtoReflect.Descriptor instead.
+func (*DetailedErrorResponseV2_MapValue) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{2, 2}
+}
+
+func (x *DetailedErrorResponseV2_MapValue) GetFields() map[string]*DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type DetailedErrorResponseV2_Attribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min     *DetailedErrorResponseV2_Value            `protobuf:"bytes,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max     *DetailedErrorResponseV2_Value            `protobuf:"bytes,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	In      *DetailedErrorResponseV2_Value            `protobuf:"bytes,3,opt,name=in,proto3,oneof" json:"in,omitempty"`
+	Pattern *string                                   `protobuf:"bytes,4,opt,name=pattern,proto3,oneof" json:"pattern,omitempty"`
+	Format  *string                                   `protobuf:"bytes,5,opt,name=format,proto3,oneof" json:"format,omitempty"`
+	Value   *DetailedErrorResponseV2_Value            `protobuf:"bytes,6,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	Values  *DetailedErrorResponseV2_ListValue        `protobuf:"bytes,7,opt,name=values,proto3,oneof" json:"values,omitempty"`
+	Field   *string                                   `protobuf:"bytes,8,opt,name=field,proto3,oneof" json:"field,omitempty"`
+	Fields  *DetailedErrorResponseV2_ListValue        `protobuf:"bytes,9,opt,name=fields,proto3,oneof" json:"fields,omitempty"`
+	Extra   map[string]*DetailedErrorResponseV2_Value `protobuf:"bytes,10,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DetailedErrorResponseV2_Attribute) Reset() {
+	*x = DetailedErrorResponseV2_Attribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailedErrorResponseV2_Attribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedErrorResponseV2_Attribute) ProtoMessage() {}
+
+func (x *DetailedErrorResponseV2_Attribute) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedErrorResponseV2_Attribute.ProtoReflect.Descriptor instead.
+func (*DetailedErrorResponseV2_Attribute) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetMin() *DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetMax() *DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetIn() *DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetPattern() string {
+	if x != nil && x.Pattern != nil {
+		return *x.Pattern
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetValue() *DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetValues() *DetailedErrorResponseV2_ListValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetField() string {
+	if x != nil && x.Field != nil {
+		return *x.Field
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetFields() *DetailedErrorResponseV2_ListValue {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Attribute) GetExtra() map[string]*DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type DetailedErrorResponseV2_Reason struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type      string                                    `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Info      *string                                   `protobuf:"bytes,2,opt,name=info,proto3,oneof" json:"info,omitempty"`
+	Attribute *DetailedErrorResponseV2_Attribute        `protobuf:"bytes,3,opt,name=attribute,proto3,oneof" json:"attribute,omitempty"`
+	Extra     map[string]*DetailedErrorResponseV2_Value `protobuf:"bytes,4,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *DetailedErrorResponse) Reset() {
-	*x = DetailedErrorResponse{}
+func (x *DetailedErrorResponseV2_Reason) Reset() {
+	*x = DetailedErrorResponseV2_Reason{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_definition_proto_msgTypes[0]
+		mi := &file_definition_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DetailedErrorResponse) String() string {
+func (x *DetailedErrorResponseV2_Reason) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DetailedErrorResponse) ProtoMessage() {}
+func (*DetailedErrorResponseV2_Reason) ProtoMessage() {}
 
-func (x *DetailedErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_definition_proto_msgTypes[0]
+func (x *DetailedErrorResponseV2_Reason) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -60,70 +1021,434 @@ func (x *DetailedErrorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DetailedErrorResponse.ProtoReflect.Descriptor instead.
-func (*DetailedErrorResponse) Descriptor() ([]byte, []int) {
-	return file_definition_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use DetailedErrorResponseV2_Reason.ProtoReflect.Descriptor instead.
+func (*DetailedErrorResponseV2_Reason) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{2, 4}
 }
 
-func (x *DetailedErrorResponse) GetError() bool {
+func (x *DetailedErrorResponseV2_Reason) GetType() string {
 	if x != nil {
-		return x.Error
+		return x.Type
 	}
-	return false
+	return ""
 }
 
-func (x *DetailedErrorResponse) GetMessage() string {
+func (x *DetailedErrorResponseV2_Reason) GetInfo() string {
+	if x != nil && x.Info != nil {
+		return *x.Info
+	}
+	return ""
+}
+
+func (x *DetailedErrorResponseV2_Reason) GetAttribute() *DetailedErrorResponseV2_Attribute {
 	if x != nil {
-		return x.Message
+		return x.Attribute
+	}
+	return nil
+}
+
+func (x *DetailedErrorResponseV2_Reason) GetExtra() map[string]*DetailedErrorResponseV2_Value {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type DetailedErrorResponseV2_FieldReasons struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key     string                            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Reasons []*DetailedErrorResponseV2_Reason `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	// path is set when the key was rendered from a field path, the other keys are free-form
+	Path *ReasonPath `protobuf:"bytes,4,opt,name=path,proto3,oneof" json:"path,omitempty"`
+}
+
+func (x *DetailedErrorResponseV2_FieldReasons) Reset() {
+	*x = DetailedErrorResponseV2_FieldReasons{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailedErrorResponseV2_FieldReasons) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedErrorResponseV2_FieldReasons) ProtoMessage() {}
+
+func (x *DetailedErrorResponseV2_FieldReasons) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedErrorResponseV2_FieldReasons.ProtoReflect.Descriptor instead.
+func (*DetailedErrorResponseV2_FieldReasons) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{2, 5}
+}
+
+func (x *DetailedErrorResponseV2_FieldReasons) GetKey() string {
+	if x != nil {
+		return x.Key
 	}
 	return ""
 }
 
-func (x *DetailedErrorResponse) GetReasons() *structpb.Struct {
+func (x *DetailedErrorResponseV2_FieldReasons) GetReasons() []*DetailedErrorResponseV2_Reason {
 	if x != nil {
 		return x.Reasons
 	}
 	return nil
 }
 
-func (x *DetailedErrorResponse) GetMetadata() *structpb.Struct {
+func (x *DetailedErrorResponseV2_FieldReasons) GetPath() *ReasonPath {
 	if x != nil {
-		return x.Metadata
+		return x.Path
 	}
 	return nil
 }
 
-func (x *DetailedErrorResponse) GetCode() string {
-	if x != nil && x.Code != nil {
-		return *x.Code
+type InternalPayload_Frame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Package string `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	File    string `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	Line    int64  `protobuf:"varint,4,opt,name=line,proto3" json:"line,omitempty"`
+}
+
+func (x *InternalPayload_Frame) Reset() {
+	*x = InternalPayload_Frame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definition_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InternalPayload_Frame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalPayload_Frame) ProtoMessage() {}
+
+func (x *InternalPayload_Frame) ProtoReflect() protoreflect.Message {
+	mi := &file_definition_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalPayload_Frame.ProtoReflect.Descriptor instead.
+func (*InternalPayload_Frame) Descriptor() ([]byte, []int) {
+	return file_definition_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *InternalPayload_Frame) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *InternalPayload_Frame) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InternalPayload_Frame) GetFile() string {
+	if x != nil {
+		return x.File
 	}
 	return ""
 }
 
+func (x *InternalPayload_Frame) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
 var File_definition_proto protoreflect.FileDescriptor
 
 var file_definition_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
-	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf4, 0x01, 0x0a, 0x15, 0x44, 0x65, 0x74,
-	0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x00, 0x52, 0x07,
-	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x01, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x02, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a,
-	0x08, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x42,
-	0x0a, 0x5a, 0x08, 0x2f, 0x3b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x83, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x2e, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a,
+	0x3d, 0x0a, 0x07, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xef,
+	0x05, 0x0a, 0x15, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x01, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x6b, 0x65,
+	0x79, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x4b, 0x65, 0x79, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x03, 0x52, 0x0d, 0x67, 0x6c, 0x6f, 0x62,
+	0x61, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x14,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x6b, 0x65, 0x79, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x13,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x07, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x06, 0x52, 0x06, 0x73, 0x70, 0x61, 0x6e, 0x49, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x21, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x07, 0x52, 0x09, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x4e, 0x0a, 0x0b, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61,
+	0x74, 0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50,
+	0x61, 0x74, 0x68, 0x73, 0x1a, 0x51, 0x0a, 0x0f, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74,
+	0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x42, 0x07, 0x0a, 0x05, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x67, 0x6c,
+	0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x42, 0x05, 0x0a, 0x03,
+	0x5f, 0x69, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x4a, 0x04, 0x08, 0x0d, 0x10, 0x0e,
+	0x22, 0xfb, 0x13, 0x0a, 0x17, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x46, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x4d, 0x0a,
+	0x0e, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0d, 0x67,
+	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d,
+	0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x14, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x13, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x02, 0x69, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1e,
+	0x0a, 0x08, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x02, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1c,
+	0x0a, 0x07, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x03, 0x52, 0x06, 0x73, 0x70, 0x61, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x04, 0x52, 0x09, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x1a,
+	0x8c, 0x03, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x6e, 0x75, 0x6c,
+	0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x4e, 0x75, 0x6c, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x09, 0x6e, 0x75, 0x6c,
+	0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6f,
+	0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x12, 0x48, 0x00, 0x52, 0x08, 0x69, 0x6e,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x75, 0x69, 0x6e, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x09, 0x75, 0x69,
+	0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x64, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52,
+	0x0b, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x4a, 0x0a, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x48, 0x00, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x47, 0x0a,
+	0x09, 0x6d, 0x61, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56,
+	0x32, 0x2e, 0x4d, 0x61, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x61,
+	0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x1a, 0x4a,
+	0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0xba, 0x01, 0x0a, 0x08, 0x4d,
+	0x61, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x4d, 0x61, 0x70, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x60, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xf1, 0x05, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x3c, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x56, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e,
+	0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56,
+	0x32, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x88, 0x01,
+	0x01, 0x12, 0x3a, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x48, 0x02, 0x52, 0x02, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a,
+	0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03,
+	0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x88, 0x01, 0x01, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48,
+	0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x12, 0x46, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x06, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x07, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x88, 0x01, 0x01, 0x12, 0x46,
+	0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x08, 0x52, 0x06, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x88, 0x01, 0x01, 0x12, 0x4a, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x78, 0x74,
+	0x72, 0x61, 0x1a, 0x5f, 0x0a, 0x0a, 0x45, 0x78, 0x74, 0x72, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x56, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f,
+	0x6d, 0x61, 0x78, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x69, 0x6e, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x42, 0x09, 0x0a, 0x07, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0xc4, 0x02, 0x0a, 0x06,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x04, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x48, 0x01, 0x52, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x47, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x31, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56,
+	0x32, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x1a, 0x5f, 0x0a, 0x0a, 0x45, 0x78,
+	0x74, 0x72, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x1a, 0x9e, 0x01, 0x0a, 0x0c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x07,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x4a, 0x04, 0x08,
+	0x03, 0x10, 0x04, 0x1a, 0x62, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x42, 0x05, 0x0a, 0x03, 0x5f, 0x69, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x74, 0x72, 0x61, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x69, 0x64,
+	0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xf9,
+	0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e,
+	0x50, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x74,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x3d, 0x0a, 0x0a, 0x50,
+	0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x52, 0x4f,
+	0x54, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x18, 0x0a, 0x14, 0x50, 0x52, 0x4f, 0x54, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x45,
+	0x4e, 0x43, 0x52, 0x59, 0x50, 0x54, 0x45, 0x44, 0x10, 0x01, 0x22, 0xce, 0x02, 0x0a, 0x0f, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x41,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x35, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x5d, 0x0a, 0x05, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x62, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3b, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x56, 0x32, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2f,
+	0x3b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -138,19 +1463,72 @@ func file_definition_proto_rawDescGZIP() []byte {
 	return file_definition_proto_rawDescData
 }
 
-var file_definition_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_definition_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_definition_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_definition_proto_goTypes = []interface{}{
-	(*DetailedErrorResponse)(nil), // 0: errors.DetailedErrorResponse
-	(*structpb.Struct)(nil),       // 1: google.protobuf.Struct
+	(InternalDetails_Protection)(0),              // 0: errors.InternalDetails.Protection
+	(*ReasonPath)(nil),                           // 1: errors.ReasonPath
+	(*DetailedErrorResponse)(nil),                // 2: errors.DetailedErrorResponse
+	(*DetailedErrorResponseV2)(nil),              // 3: errors.DetailedErrorResponseV2
+	(*InternalDetails)(nil),                      // 4: errors.InternalDetails
+	(*InternalPayload)(nil),                      // 5: errors.InternalPayload
+	(*ReasonPath_Segment)(nil),                   // 6: errors.ReasonPath.Segment
+	nil,                                          // 7: errors.DetailedErrorResponse.FieldPathsEntry
+	(*DetailedErrorResponseV2_Value)(nil),        // 8: errors.DetailedErrorResponseV2.Value
+	(*DetailedErrorResponseV2_ListValue)(nil),    // 9: errors.DetailedErrorResponseV2.ListValue
+	(*DetailedErrorResponseV2_MapValue)(nil),     // 10: errors.DetailedErrorResponseV2.MapValue
+	(*DetailedErrorResponseV2_Attribute)(nil),    // 11: errors.DetailedErrorResponseV2.Attribute
+	(*DetailedErrorResponseV2_Reason)(nil),       // 12: errors.DetailedErrorResponseV2.Reason
+	(*DetailedErrorResponseV2_FieldReasons)(nil), // 13: errors.DetailedErrorResponseV2.FieldReasons
+	nil,                           // 14: errors.DetailedErrorResponseV2.MetadataEntry
+	nil,                           // 15: errors.DetailedErrorResponseV2.MapValue.FieldsEntry
+	nil,                           // 16: errors.DetailedErrorResponseV2.Attribute.ExtraEntry
+	nil,                           // 17: errors.DetailedErrorResponseV2.Reason.ExtraEntry
+	(*InternalPayload_Frame)(nil), // 18: errors.InternalPayload.Frame
+	nil,                           // 19: errors.InternalPayload.MetadataEntry
+	(*structpb.Struct)(nil),       // 20: google.protobuf.Struct
+	(*structpb.ListValue)(nil),    // 21: google.protobuf.ListValue
+	(structpb.NullValue)(0),       // 22: google.protobuf.NullValue
 }
 var file_definition_proto_depIdxs = []int32{
-	1, // 0: errors.DetailedErrorResponse.reasons:type_name -> google.protobuf.Struct
-	1, // 1: errors.DetailedErrorResponse.metadata:type_name -> google.protobuf.Struct
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	6,  // 0: errors.ReasonPath.segments:type_name -> errors.ReasonPath.Segment
+	20, // 1: errors.DetailedErrorResponse.reasons:type_name -> google.protobuf.Struct
+	20, // 2: errors.DetailedErrorResponse.metadata:type_name -> google.protobuf.Struct
+	21, // 3: errors.DetailedErrorResponse.global_reasons:type_name -> google.protobuf.ListValue
+	7,  // 4: errors.DetailedErrorResponse.field_paths:type_name -> errors.DetailedErrorResponse.FieldPathsEntry
+	13, // 5: errors.DetailedErrorResponseV2.reasons:type_name -> errors.DetailedErrorResponseV2.FieldReasons
+	12, // 6: errors.DetailedErrorResponseV2.global_reasons:type_name -> errors.DetailedErrorResponseV2.Reason
+	14, // 7: errors.DetailedErrorResponseV2.metadata:type_name -> errors.DetailedErrorResponseV2.MetadataEntry
+	0,  // 8: errors.InternalDetails.protection:type_name -> errors.InternalDetails.Protection
+	19, // 9: errors.InternalPayload.metadata:type_name -> errors.InternalPayload.MetadataEntry
+	18, // 10: errors.InternalPayload.frames:type_name -> errors.InternalPayload.Frame
+	1,  // 11: errors.DetailedErrorResponse.FieldPathsEntry.value:type_name -> errors.ReasonPath
+	22, // 12: errors.DetailedErrorResponseV2.Value.null_value:type_name -> google.protobuf.NullValue
+	9,  // 13: errors.DetailedErrorResponseV2.Value.list_value:type_name -> errors.DetailedErrorResponseV2.ListValue
+	10, // 14: errors.DetailedErrorResponseV2.Value.map_value:type_name -> errors.DetailedErrorResponseV2.MapValue
+	8,  // 15: errors.DetailedErrorResponseV2.ListValue.values:type_name -> errors.DetailedErrorResponseV2.Value
+	15, // 16: errors.DetailedErrorResponseV2.MapValue.fields:type_name -> errors.DetailedErrorResponseV2.MapValue.FieldsEntry
+	8,  // 17: errors.DetailedErrorResponseV2.Attribute.min:type_name -> errors.DetailedErrorResponseV2.Value
+	8,  // 18: errors.DetailedErrorResponseV2.Attribute.max:type_name -> errors.DetailedErrorResponseV2.Value
+	8,  // 19: errors.DetailedErrorResponseV2.Attribute.in:type_name -> errors.DetailedErrorResponseV2.Value
+	8,  // 20: errors.DetailedErrorResponseV2.Attribute.value:type_name -> errors.DetailedErrorResponseV2.Value
+	9,  // 21: errors.DetailedErrorResponseV2.Attribute.values:type_name -> errors.DetailedErrorResponseV2.ListValue
+	9,  // 22: errors.DetailedErrorResponseV2.Attribute.fields:type_name -> errors.DetailedErrorResponseV2.ListValue
+	16, // 23: errors.DetailedErrorResponseV2.Attribute.extra:type_name -> errors.DetailedErrorResponseV2.Attribute.ExtraEntry
+	11, // 24: errors.DetailedErrorResponseV2.Reason.attribute:type_name -> errors.DetailedErrorResponseV2.Attribute
+	17, // 25: errors.DetailedErrorResponseV2.Reason.extra:type_name -> errors.DetailedErrorResponseV2.Reason.ExtraEntry
+	12, // 26: errors.DetailedErrorResponseV2.FieldReasons.reasons:type_name -> errors.DetailedErrorResponseV2.Reason
+	1,  // 27: errors.DetailedErrorResponseV2.FieldReasons.path:type_name -> errors.ReasonPath
+	8,  // 28: errors.DetailedErrorResponseV2.MetadataEntry.value:type_name -> errors.DetailedErrorResponseV2.Value
+	8,  // 29: errors.DetailedErrorResponseV2.MapValue.FieldsEntry.value:type_name -> errors.DetailedErrorResponseV2.Value
+	8,  // 30: errors.DetailedErrorResponseV2.Attribute.ExtraEntry.value:type_name -> errors.DetailedErrorResponseV2.Value
+	8,  // 31: errors.DetailedErrorResponseV2.Reason.ExtraEntry.value:type_name -> errors.DetailedErrorResponseV2.Value
+	8,  // 32: errors.InternalPayload.MetadataEntry.value:type_name -> errors.DetailedErrorResponseV2.Value
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_definition_proto_init() }
@@ -160,6 +1538,18 @@ func file_definition_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_definition_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReasonPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DetailedErrorResponse); i {
 			case 0:
 				return &v.state
@@ -171,20 +1561,171 @@ func file_definition_proto_init() {
 				return nil
 			}
 		}
+		file_definition_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetailedErrorResponseV2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InternalDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InternalPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReasonPath_Segment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetailedErrorResponseV2_Value); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetailedErrorResponseV2_ListValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetailedErrorResponseV2_MapValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetailedErrorResponseV2_Attribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetailedErrorResponseV2_Reason); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DetailedErrorResponseV2_FieldReasons); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definition_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InternalPayload_Frame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_definition_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_definition_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_definition_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*ReasonPath_Segment_Key)(nil),
+		(*ReasonPath_Segment_Index)(nil),
+	}
+	file_definition_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*DetailedErrorResponseV2_Value_NullValue)(nil),
+		(*DetailedErrorResponseV2_Value_BoolValue)(nil),
+		(*DetailedErrorResponseV2_Value_IntValue)(nil),
+		(*DetailedErrorResponseV2_Value_UintValue)(nil),
+		(*DetailedErrorResponseV2_Value_DoubleValue)(nil),
+		(*DetailedErrorResponseV2_Value_StringValue)(nil),
+		(*DetailedErrorResponseV2_Value_ListValue)(nil),
+		(*DetailedErrorResponseV2_Value_MapValue)(nil),
 	}
-	file_definition_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_definition_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_definition_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_definition_proto_msgTypes[12].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_definition_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_definition_proto_goTypes,
 		DependencyIndexes: file_definition_proto_depIdxs,
+		EnumInfos:         file_definition_proto_enumTypes,
 		MessageInfos:      file_definition_proto_msgTypes,
 	}.Build()
 	File_definition_proto = out.File
@@ -0,0 +1,404 @@
+package errors
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func marshalDetailsV2(details *ErrorDetails) (*anypb.Any, error) {
+	de := &DetailedErrorResponseV2{
+		Error: true,
+	}
+
+	if details.ID != "" {
+		de.Id = &details.ID
+	}
+
+	if details.TraceID != "" {
+		de.TraceId = &details.TraceID
+		de.SpanId = &details.SpanID
+	}
+
+	de.Retryable = details.Retryable
+
+	if details.Message != nil {
+		de.Message = *details.Message
+	}
+
+	if details.InternalCode != nil {
+		de.Code = details.InternalCode
+	}
+
+	for _, key := range details.OrderedReasonKeys() {
+		list, err := reasonsToV2(details.Reasons[key])
+		if err != nil {
+			return nil, err
+		}
+
+		fr := &DetailedErrorResponseV2_FieldReasons{
+			Key:     key,
+			Reasons: list,
+		}
+
+		if path, ok := details.FieldPaths[key]; ok {
+			fr.Path = path.toProto()
+		}
+
+		de.Reasons = append(de.Reasons, fr)
+	}
+
+	if len(details.GlobalReasons) > 0 {
+		list, err := reasonsToV2(details.GlobalReasons)
+		if err != nil {
+			return nil, err
+		}
+
+		de.GlobalReasons = list
+	}
+
+	if details.IncludeMetadata && len(details.Metadata) > 0 {
+		normalized, err := normalizeMetadata(details.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		md, err := mapToV2(normalized)
+		if err != nil {
+			return nil, err
+		}
+
+		de.Metadata = md
+		de.PublicMetadataKeys = details.publicMetadataKeys()
+	}
+
+	return anypb.New(de)
+}
+
+func unmarshalDetailsV2(anyErr *anypb.Any) (*ErrorDetails, error) {
+	dErr := &DetailedErrorResponseV2{}
+	if err := anyErr.UnmarshalTo(dErr); err != nil {
+		return nil, err
+	}
+
+	var details = &ErrorDetails{
+		Version:   DetailsV2,
+		ID:        dErr.GetId(),
+		TraceID:   dErr.GetTraceId(),
+		SpanID:    dErr.GetSpanId(),
+		Retryable: dErr.Retryable,
+	}
+
+	if dErr.Message != "" {
+		details.Message = &dErr.Message
+	}
+
+	if dErr.Code != nil {
+		details.InternalCode = dErr.Code
+	}
+
+	if len(dErr.Reasons) > 0 {
+		details.Reasons = make(map[string][]Reason, len(dErr.Reasons))
+		details.ReasonKeys = make([]string, 0, len(dErr.Reasons))
+
+		for _, fr := range dErr.Reasons {
+			if _, ok := details.Reasons[fr.Key]; !ok {
+				details.ReasonKeys = append(details.ReasonKeys, fr.Key)
+				if fr.Path != nil {
+					if details.FieldPaths == nil {
+						details.FieldPaths = make(map[string]FieldPath)
+					}
+
+					details.FieldPaths[fr.Key] = pathFromProto(fr.Path)
+				}
+			}
+
+			details.Reasons[fr.Key] = append(details.Reasons[fr.Key], reasonsFromV2(fr.Reasons)...)
+		}
+	}
+
+	if len(dErr.GlobalReasons) > 0 {
+		details.GlobalReasons = reasonsFromV2(dErr.GlobalReasons)
+	}
+
+	if dErr.Metadata != nil {
+		details.Metadata = mapFromV2(dErr.Metadata)
+		details.setPublicMetadataKeys(dErr.PublicMetadataKeys)
+	}
+
+	return details, nil
+}
+
+func reasonsToV2(items []Reason) ([]*DetailedErrorResponseV2_Reason, error) {
+	list := make([]*DetailedErrorResponseV2_Reason, len(items))
+	for i, item := range items {
+		d, err := normalizeValue(item.ToHashMap())
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := d.(map[string]interface{})
+		r, err := reasonToV2(m)
+		if err != nil {
+			return nil, err
+		}
+
+		list[i] = r
+	}
+
+	return list, nil
+}
+
+// reasonToV2 works on the hash map, so custom Reason implementations are supported as well
+func reasonToV2(d map[string]interface{}) (*DetailedErrorResponseV2_Reason, error) {
+	r := &DetailedErrorResponseV2_Reason{}
+
+	for k, v := range d {
+		switch k {
+		case "type":
+			r.Type = fmt.Sprintf("%v", v)
+		case "info":
+			info := fmt.Sprintf("%v", v)
+			r.Info = &info
+		case "attribute":
+			attr, err := attributeToV2(v)
+			if err != nil {
+				return nil, err
+			}
+
+			r.Attribute = attr
+		default:
+			value, err := valueToV2(v)
+			if err != nil {
+				return nil, err
+			}
+
+			if r.Extra == nil {
+				r.Extra = make(map[string]*DetailedErrorResponseV2_Value)
+			}
+
+			r.Extra[k] = value
+		}
+	}
+
+	return r, nil
+}
+
+func attributeToV2(v interface{}) (*DetailedErrorResponseV2_Attribute, error) {
+	d, ok := v.(map[string]interface{})
+	if !ok || len(d) == 0 {
+		return nil, nil
+	}
+
+	attr := &DetailedErrorResponseV2_Attribute{}
+	for k, v := range d {
+		value, err := valueToV2(v)
+		if err != nil {
+			return nil, err
+		}
+
+		switch k {
+		case "min":
+			attr.Min = value
+		case "max":
+			attr.Max = value
+		case "in":
+			attr.In = value
+		case "value":
+			attr.Value = value
+		case "pattern":
+			pattern := fmt.Sprintf("%v", v)
+			attr.Pattern = &pattern
+		case "format":
+			format := fmt.Sprintf("%v", v)
+			attr.Format = &format
+		case "field":
+			field := fmt.Sprintf("%v", v)
+			attr.Field = &field
+		case "values":
+			attr.Values = value.GetListValue()
+		case "fields":
+			attr.Fields = value.GetListValue()
+		default:
+			if attr.Extra == nil {
+				attr.Extra = make(map[string]*DetailedErrorResponseV2_Value)
+			}
+
+			attr.Extra[k] = value
+		}
+	}
+
+	return attr, nil
+}
+
+func reasonsFromV2(items []*DetailedErrorResponseV2_Reason) []Reason {
+	list := make([]Reason, len(items))
+	for i, item := range items {
+		r := reason{
+			Type:      item.Type,
+			Info:      item.Info,
+			Attribute: attributeFromV2(item.Attribute),
+		}
+
+		if len(item.Extra) > 0 {
+			r.Extra = mapFromV2(item.Extra)
+		}
+
+		list[i] = r
+	}
+
+	return list
+}
+
+func attributeFromV2(attr *DetailedErrorResponseV2_Attribute) *Attribute {
+	if attr == nil {
+		return nil
+	}
+
+	a := &Attribute{
+		Min:     valueFromV2(attr.Min),
+		Max:     valueFromV2(attr.Max),
+		In:      valueFromV2(attr.In),
+		Pattern: attr.Pattern,
+		Format:  attr.Format,
+		Value:   valueFromV2(attr.Value),
+		Field:   attr.Field,
+	}
+
+	if attr.Values != nil {
+		a.Values = listFromV2(attr.Values)
+	}
+
+	if attr.Fields != nil {
+		a.Fields = listFromV2(attr.Fields)
+	}
+
+	if len(attr.Extra) > 0 {
+		a.Extra = mapFromV2(attr.Extra)
+	}
+
+	return a
+}
+
+func mapToV2(d map[string]interface{}) (map[string]*DetailedErrorResponseV2_Value, error) {
+	m := make(map[string]*DetailedErrorResponseV2_Value, len(d))
+	for k, v := range d {
+		value, err := valueToV2(v)
+		if err != nil {
+			return nil, err
+		}
+
+		m[k] = value
+	}
+
+	return m, nil
+}
+
+func mapFromV2(m map[string]*DetailedErrorResponseV2_Value) map[string]interface{} {
+	d := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		d[k] = valueFromV2(v)
+	}
+
+	return d
+}
+
+func listFromV2(l *DetailedErrorResponseV2_ListValue) []interface{} {
+	list := make([]interface{}, len(l.GetValues()))
+	for i, v := range l.GetValues() {
+		list[i] = valueFromV2(v)
+	}
+
+	return list
+}
+
+// valueToV2 accepts the same types as structpb.NewValue, but keeps integers as integers
+func valueToV2(v interface{}) (*DetailedErrorResponseV2_Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_NullValue{NullValue: structpb.NullValue_NULL_VALUE}}, nil
+	case bool:
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_BoolValue{BoolValue: v}}, nil
+	case string:
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_StringValue{StringValue: v}}, nil
+	case []byte:
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_StringValue{StringValue: base64.StdEncoding.EncodeToString(v)}}, nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_IntValue{IntValue: i}}, nil
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", v, err)
+		}
+
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_DoubleValue{DoubleValue: f}}, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_IntValue{IntValue: rv.Int()}}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_UintValue{UintValue: rv.Uint()}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_DoubleValue{DoubleValue: rv.Float()}}, nil
+	case reflect.Slice, reflect.Array:
+		list := &DetailedErrorResponseV2_ListValue{Values: make([]*DetailedErrorResponseV2_Value, rv.Len())}
+		for i := range list.Values {
+			value, err := valueToV2(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			list.Values[i] = value
+		}
+
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_ListValue{ListValue: list}}, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("invalid map key type: %v", rv.Type().Key())
+		}
+
+		m := &DetailedErrorResponseV2_MapValue{Fields: make(map[string]*DetailedErrorResponseV2_Value, rv.Len())}
+		iter := rv.MapRange()
+		for iter.Next() {
+			value, err := valueToV2(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			m.Fields[iter.Key().String()] = value
+		}
+
+		return &DetailedErrorResponseV2_Value{Kind: &DetailedErrorResponseV2_Value_MapValue{MapValue: m}}, nil
+	}
+
+	return nil, fmt.Errorf("invalid type: %T", v)
+}
+
+func valueFromV2(v *DetailedErrorResponseV2_Value) interface{} {
+	switch kind := v.GetKind().(type) {
+	case *DetailedErrorResponseV2_Value_BoolValue:
+		return kind.BoolValue
+	case *DetailedErrorResponseV2_Value_IntValue:
+		return kind.IntValue
+	case *DetailedErrorResponseV2_Value_UintValue:
+		return kind.UintValue
+	case *DetailedErrorResponseV2_Value_DoubleValue:
+		return kind.DoubleValue
+	case *DetailedErrorResponseV2_Value_StringValue:
+		return kind.StringValue
+	case *DetailedErrorResponseV2_Value_ListValue:
+		return listFromV2(kind.ListValue)
+	case *DetailedErrorResponseV2_Value_MapValue:
+		return mapFromV2(kind.MapValue.GetFields())
+	default:
+		return nil
+	}
+}
@@ -4,23 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"runtime"
 	"strconv"
+	"time"
 
 	response "github.com/poorly-written/grpc-http-response"
 	"github.com/poorly-written/grpc-http-response/codes"
 	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 type DetailedError interface {
 	error
+	ID() string
+	TraceContext() (TraceContext, bool)
+	TraceID() string
+	SpanID() string
 	Unwrap() error
 	Original() error
 	GRPCStatus() *status.Status
 	HasError() bool
 	Message(msg string) DetailedError
+	Messagef(format string, args ...interface{}) DetailedError
+	SafeMessage() string
 	AddHeader(key string, value ...string) DetailedError
 	RemoveHeader(key string) DetailedError
 	GetHeaders() metadata.MD
@@ -28,42 +35,114 @@ type DetailedError interface {
 	RemoveTrailer(key string) DetailedError
 	GetTrailers() metadata.MD
 	StackFrames() []frame
+	StackTrace() []info
+	RemoteStackFrames() []info
 	ShouldBeReported() DetailedError
+	Retryable() DetailedError
+	NotRetryable() DetailedError
+	RetryAfter(d time.Duration) DetailedError
+	IsRetryable() bool
+	GetRetryAfter() (time.Duration, bool)
 	IsReportable() bool
 	Code(code codes.Code) DetailedError
+	GetCode() codes.Code
 	InternalCode(errorCode string) DetailedError
+	GetInternalCode() (string, bool)
 	Context(ctx context.Context, extractMetadata ...bool) DetailedError
 	AddMetadata(key string, value interface{}) DetailedError
+	AddMetadataWithVisibility(key string, value interface{}, visibility Visibility) DetailedError
 	GetMetadata() map[string]interface{}
+	GetMetadataFor(audience Visibility) map[string]interface{}
+	MetadataVisibility(key string) Visibility
 	HasMetadata(keys ...string) bool
 	IncludeMetadata() DetailedError
 	AddReason(key string, reason any) DetailedError
+	AddFieldReason(path FieldPath, reason any) DetailedError
+	AddGlobalReason(reason any) DetailedError
 	GetReasons() map[string][]Reason
+	GetOrderedReasons() []FieldReasons
+	GetGlobalReasons() []Reason
 	HasReasons(keys ...string) bool
+	HasFieldReasons(paths ...FieldPath) bool
+	HasGlobalReasons() bool
 	Append(key string, value interface{}) DetailedError
 	Merge(err error) DetailedError
 	Send() error
 }
 
+// the maps of err are allocated on the first write, most errors never use them
 type err struct {
-	message         string
-	original        error
-	frames          []frame
-	headers         metadata.MD
-	trailers        metadata.MD
-	reasons         map[string][]Reason
-	reportable      bool
-	code            codes.Code
+	id string
+	// idGenerated is true unless the ID was seeded or received, the ID is generated on the first `ID` call
+	idGenerated   bool
+	trace         *TraceContext
+	message       string
+	safeMessage   *string
+	original      error
+	frames        []frame
+	remoteFrames  []info
+	headers       metadata.MD
+	trailers      metadata.MD
+	reasons       map[string][]Reason
+	reasonKeys    []string
+	paths         map[string]FieldPath
+	globalReasons []Reason
+	reportable    bool
+	retryable     *bool
+	retryAfter    *time.Duration
+	code          codes.Code
+	// grpcCode overrides the gRPC code of `code`. e.g. the plain errors keep Unknown at a trust boundary
+	grpcCode        *grpccodes.Code
 	internalCode    *string
 	metadata        map[string]interface{}
+	visibility      map[string]Visibility
 	includeMetadata bool
-	ctx             context.Context
+	// skipInternalDetails is set on the errors sanitized at a trust boundary
+	skipInternalDetails bool
+	ctx                 context.Context
+	// factory is nil for the errors created without one. e.g. `Sanitize`
+	factory *Factory
 }
 
 func (e *err) Error() string {
 	return e.message
 }
 
+// ID returns the unique ID of the error, it's preserved by `New` when the error is rehydrated
+func (e *err) ID() string {
+	if e.id == "" && e.idGenerated {
+		e.id = e.factory.idGenerator()(e.ctx)
+	}
+
+	return e.id
+}
+
+// TraceContext returns the trace context captured from the context of the error,
+// or received from the service the error was rehydrated from
+func (e *err) TraceContext() (TraceContext, bool) {
+	if e.trace == nil {
+		return TraceContext{}, false
+	}
+
+	return *e.trace, true
+}
+
+func (e *err) TraceID() string {
+	if e.trace == nil {
+		return ""
+	}
+
+	return e.trace.TraceID
+}
+
+func (e *err) SpanID() string {
+	if e.trace == nil {
+		return ""
+	}
+
+	return e.trace.SpanID
+}
+
 func (e *err) Unwrap() error {
 	return e.original
 }
@@ -73,30 +152,82 @@ func (e *err) Original() error {
 }
 
 func (e *err) GRPCStatus() *status.Status {
-	st := status.New(e.code.GrpcCode(), e.message)
+	message := e.factory.redactor().RedactString(e.message)
+	grpcCode := e.code.GrpcCode()
+	if e.grpcCode != nil {
+		grpcCode = *e.grpcCode
+	}
+
+	st := status.New(grpcCode, message)
 
-	marshaled, err := errorMarshaler(&ErrorDetails{
-		Message:         &e.message,
-		InternalCode:    e.internalCode,
-		Reasons:         e.reasons,
-		IncludeMetadata: e.includeMetadata,
-		Metadata:        e.metadata,
-	})
+	// public metadata is always sent, internal metadata only when `IncludeMetadata` is called
+	audience := VisibilityPublic
+	if e.includeMetadata {
+		audience = VisibilityInternal
+	}
+
+	// the metadata is normalized with the factory's time format before the redactor normalizes it
+	md, err := normalizeMetadataWith(e.GetMetadataFor(audience), e.factory)
+	if err != nil {
+		return status.New(codes.InternalServerError.GrpcCode(), err.Error())
+	}
+
+	md = redactMap(e.factory.redactor(), md)
+
+	details := &ErrorDetails{
+		ID:                 e.ID(),
+		TraceID:            e.TraceID(),
+		SpanID:             e.SpanID(),
+		Message:            &message,
+		InternalCode:       e.internalCode,
+		Reasons:            redactKeyedReasons(e.factory.redactor(), e.reasons),
+		ReasonKeys:         e.reasonKeys,
+		FieldPaths:         e.paths,
+		GlobalReasons:      redactReasons(e.factory.redactor(), e.globalReasons),
+		Retryable:          e.retryable,
+		IncludeMetadata:    len(md) > 0,
+		Metadata:           md,
+		MetadataVisibility: e.visibilityOf(md),
+	}
+
+	marshaled, err := marshalDetails(e.factory.marshaler(), e.factory.detailsVersion(), details)
 
 	// error occurred during error marshalling
 	if err != nil {
 		return status.New(codes.InternalServerError.GrpcCode(), err.Error())
 	}
 
-	if marshaled == nil {
+	if len(marshaled) == 0 {
 		return st
 	}
 
-	dSt, err := st.WithDetails(marshaled)
+	dSt := st
+	for _, detail := range marshaled {
+		if dSt, err = dSt.WithDetails(detail); err != nil {
+			return status.New(codes.InternalServerError.GrpcCode(), err.Error())
+		}
+	}
+
+	// the standard detail is understood by the clients of the other languages
+	if e.retryAfter != nil {
+		if dSt, err = dSt.WithDetails(retryInfo(*e.retryAfter)); err != nil {
+			return status.New(codes.InternalServerError.GrpcCode(), err.Error())
+		}
+	}
+
+	if e.factory.internalDetailsKey() == nil || e.skipInternalDetails {
+		return dSt
+	}
+
+	internal, err := e.internalDetails()
 	if err != nil {
 		return status.New(codes.InternalServerError.GrpcCode(), err.Error())
 	}
 
+	if dSt, err = dSt.WithDetails(internal); err != nil {
+		return status.New(codes.InternalServerError.GrpcCode(), err.Error())
+	}
+
 	return dSt
 }
 
@@ -106,11 +237,35 @@ func (e *err) HasError() bool {
 
 func (e *err) Message(msg string) DetailedError {
 	e.message = msg
+	e.safeMessage = nil
 
 	return e
 }
 
+// Messagef sets a formatted message, the arguments not wrapped with Safe are masked in `SafeMessage`
+func (e *err) Messagef(format string, args ...interface{}) DetailedError {
+	full, safe := sprintfSafe(format, args...)
+	e.message = full
+	e.safeMessage = &safe
+
+	return e
+}
+
+// SafeMessage returns the message with only the safe parts, it can be sent off-box (e.g. crash reports).
+// If the message wasn't set with Newf or Messagef, it's the message passed through the redactor.
+func (e *err) SafeMessage() string {
+	if e.safeMessage != nil {
+		return *e.safeMessage
+	}
+
+	return e.factory.redactor().RedactString(e.message)
+}
+
 func (e *err) AddHeader(key string, value ...string) DetailedError {
+	if e.headers == nil {
+		e.headers = make(metadata.MD)
+	}
+
 	e.headers[key] = append(e.headers[key], value...)
 
 	return e
@@ -123,10 +278,18 @@ func (e *err) RemoveHeader(key string) DetailedError {
 }
 
 func (e *err) GetHeaders() metadata.MD {
+	if e.headers == nil {
+		e.headers = make(metadata.MD)
+	}
+
 	return e.headers
 }
 
 func (e *err) AddTrailer(key string, value ...string) DetailedError {
+	if e.trailers == nil {
+		e.trailers = make(metadata.MD)
+	}
+
 	e.trailers[key] = append(e.trailers[key], value...)
 
 	return e
@@ -139,6 +302,10 @@ func (e *err) RemoveTrailer(key string) DetailedError {
 }
 
 func (e *err) GetTrailers() metadata.MD {
+	if e.trailers == nil {
+		e.trailers = make(metadata.MD)
+	}
+
 	return e.trailers
 }
 
@@ -146,12 +313,57 @@ func (e *err) StackFrames() []frame {
 	return e.frames
 }
 
+// RemoteStackFrames returns the stack trace of the trusted service the error was received from,
+// it's only available when the internal details key is configured on both sides
+func (e *err) RemoteStackFrames() []info {
+	return e.remoteFrames
+}
+
 func (e *err) ShouldBeReported() DetailedError {
 	e.reportable = true
 
 	return e
 }
 
+func (e *err) Retryable() DetailedError {
+	retryable := true
+	e.retryable = &retryable
+
+	return e
+}
+
+// NotRetryable marks the error as not retryable even if its code is retryable
+func (e *err) NotRetryable() DetailedError {
+	retryable := false
+	e.retryable = &retryable
+	e.retryAfter = nil
+
+	return e
+}
+
+// RetryAfter marks the error as retryable after the delay
+func (e *err) RetryAfter(d time.Duration) DetailedError {
+	e.retryAfter = &d
+
+	return e.Retryable()
+}
+
+func (e *err) IsRetryable() bool {
+	if e.retryable != nil {
+		return *e.retryable
+	}
+
+	return e.factory.isRetryableCode(e.code)
+}
+
+func (e *err) GetRetryAfter() (time.Duration, bool) {
+	if e.retryAfter == nil {
+		return 0, false
+	}
+
+	return *e.retryAfter, true
+}
+
 func (e *err) IsReportable() bool {
 	return e.reportable
 }
@@ -162,20 +374,41 @@ func (e *err) Code(code codes.Code) DetailedError {
 	return e
 }
 
+func (e *err) GetCode() codes.Code {
+	return e.code
+}
+
 func (e *err) InternalCode(code string) DetailedError {
 	e.internalCode = &code
 
 	return e
 }
 
+func (e *err) GetInternalCode() (string, bool) {
+	if e.internalCode == nil {
+		return "", false
+	}
+
+	return *e.internalCode, true
+}
+
 func (e *err) Context(ctx context.Context, extractMetadata ...bool) DetailedError {
 	e.ctx = ctx
 
+	if id := e.factory.requestIDFromContext(ctx); e.idGenerated && id != "" {
+		e.id = id
+		e.idGenerated = false
+	}
+
+	if tc := e.factory.traceContextFrom(ctx); tc != nil {
+		e.trace = tc
+	}
+
 	if len(extractMetadata) == 0 || extractMetadata[0] == false {
 		return e
 	}
 
-	for k, v := range contextualMetadataExtractor(ctx) {
+	for k, v := range e.factory.extractor()(ctx) {
 		e.AddMetadata(k, v)
 	}
 
@@ -189,15 +422,63 @@ func (e *err) IncludeMetadata() DetailedError {
 }
 
 func (e *err) AddMetadata(key string, value interface{}) DetailedError {
+	if e.metadata == nil {
+		e.metadata = make(map[string]interface{})
+	}
+
 	e.metadata[key] = value
 
 	return e
 }
 
+func (e *err) AddMetadataWithVisibility(key string, value interface{}, visibility Visibility) DetailedError {
+	if e.visibility == nil {
+		e.visibility = make(map[string]Visibility)
+	}
+
+	e.visibility[key] = visibility
+
+	return e.AddMetadata(key, value)
+}
+
+// GetMetadata returns all the metadata regardless of the visibility
 func (e *err) GetMetadata() map[string]interface{} {
+	if e.metadata == nil {
+		e.metadata = make(map[string]interface{})
+	}
+
 	return e.metadata
 }
 
+// GetMetadataFor returns the metadata visible to the audience
+func (e *err) GetMetadataFor(audience Visibility) map[string]interface{} {
+	md := make(map[string]interface{}, len(e.metadata))
+	for k, v := range e.metadata {
+		if e.MetadataVisibility(k) <= audience {
+			md[k] = v
+		}
+	}
+
+	return md
+}
+
+func (e *err) MetadataVisibility(key string) Visibility {
+	if visibility, ok := e.visibility[key]; ok {
+		return visibility
+	}
+
+	return e.factory.defaultMetadataVisibility()
+}
+
+func (e *err) visibilityOf(md map[string]interface{}) map[string]Visibility {
+	visibility := make(map[string]Visibility, len(md))
+	for k := range md {
+		visibility[k] = e.MetadataVisibility(k)
+	}
+
+	return visibility
+}
+
 func (e *err) HasMetadata(keys ...string) bool {
 	if len(e.metadata) == 0 {
 		return false
@@ -218,49 +499,85 @@ func (e *err) Merge(err error) DetailedError {
 		return e
 	}
 
-	for key, reasons := range de.GetReasons() {
-		if _, ok := e.reasons[key]; !ok {
-			e.reasons[key] = reasons
-		} else {
-			e.reasons[key] = append(e.reasons[key], reasons...)
+	for _, fr := range de.GetOrderedReasons() {
+		e.appendReasons(fr.Key, fr.Reasons...)
+		if fr.FieldPath {
+			e.setPath(fr.Key, fr.Path)
 		}
 	}
 
+	e.globalReasons = append(e.globalReasons, de.GetGlobalReasons()...)
+
 	for key, md := range de.GetMetadata() {
-		e.metadata[key] = md
+		e.AddMetadataWithVisibility(key, md, de.MetadataVisibility(key))
 	}
 
 	for k, headers := range de.GetHeaders() {
-		e.headers.Append(k, headers...)
+		e.AddHeader(k, headers...)
 	}
 
 	for k, trailers := range de.GetTrailers() {
-		e.trailers.Append(k, trailers...)
+		e.AddTrailer(k, trailers...)
 	}
 
 	return e
 }
 
-func (e *err) AddReason(key string, reason any) DetailedError {
+// setPath keeps the path the key was rendered from, it's sent with the reasons
+func (e *err) setPath(key string, path FieldPath) {
+	if e.paths == nil {
+		e.paths = make(map[string]FieldPath)
+	}
+
+	e.paths[key] = path
+}
+
+// appendReasons keeps track of the insertion order of the keys
+func (e *err) appendReasons(key string, reasons ...Reason) {
+	if e.reasons == nil {
+		e.reasons = make(map[string][]Reason)
+	}
+
 	if _, ok := e.reasons[key]; !ok {
-		e.reasons[key] = make([]Reason, 0)
+		e.reasons[key] = make([]Reason, 0, len(reasons))
+		e.reasonKeys = append(e.reasonKeys, key)
 	}
 
-	var r Reason
+	e.reasons[key] = append(e.reasons[key], reasons...)
+}
+
+func toReason(reason any) Reason {
 	switch v := reason.(type) {
 	case Reason:
-		r = v
+		return v
 	case error:
-		r = SimpleReason(v.Error())
+		return SimpleReason(v.Error())
 	default:
-		r = SimpleReason(fmt.Sprintf("%v", v))
+		return SimpleReason(fmt.Sprintf("%v", v))
 	}
+}
+
+func (e *err) AddReason(key string, reason any) DetailedError {
+	e.appendReasons(key, toReason(reason))
+
+	return e
+}
 
-	e.reasons[key] = append(e.reasons[key], r)
+// AddGlobalReason adds a reason that applies to the whole request rather than a single key
+func (e *err) AddGlobalReason(reason any) DetailedError {
+	e.globalReasons = append(e.globalReasons, toReason(reason))
 
 	return e
 }
 
+// AddFieldReason adds a reason for a nested field, the key is rendered with the configured path style
+func (e *err) AddFieldReason(path FieldPath, reason any) DetailedError {
+	key := path.Format(e.factory.pathStyle())
+	e.setPath(key, path)
+
+	return e.AddReason(key, reason)
+}
+
 func (e *err) HasReasons(keys ...string) bool {
 	if len(e.reasons) == 0 {
 		return false
@@ -275,10 +592,42 @@ func (e *err) HasReasons(keys ...string) bool {
 	return true
 }
 
+func (e *err) HasFieldReasons(paths ...FieldPath) bool {
+	keys := make([]string, len(paths))
+	for i, path := range paths {
+		keys[i] = path.Format(e.factory.pathStyle())
+	}
+
+	return e.HasReasons(keys...)
+}
+
+func (e *err) HasGlobalReasons() bool {
+	return len(e.globalReasons) > 0
+}
+
 func (e *err) GetReasons() map[string][]Reason {
+	if e.reasons == nil {
+		e.reasons = make(map[string][]Reason)
+	}
+
 	return e.reasons
 }
 
+// GetOrderedReasons returns the keyed reasons in the order the keys were added
+func (e *err) GetOrderedReasons() []FieldReasons {
+	list := make([]FieldReasons, len(e.reasonKeys))
+	for i, key := range e.reasonKeys {
+		path, ok := e.paths[key]
+		list[i] = FieldReasons{Key: key, Reasons: e.reasons[key], FieldPath: ok, Path: path}
+	}
+
+	return list
+}
+
+func (e *err) GetGlobalReasons() []Reason {
+	return e.globalReasons
+}
+
 // Append method appends either to reasons or metadata based on the value provided.
 // If the value is a type of `Reason`, then append forwards the call to the
 // `AddReason` function. Otherwise, it forwards the call to the `AddMetadata` function.
@@ -297,17 +646,31 @@ func (e *err) Send() error {
 	}
 
 	if e.headers.Len() > 0 {
-		grpc.SetHeader(e.ctx, e.headers)
+		grpc.SetHeader(e.ctx, redactMD(e.factory.redactor(), e.headers))
 	}
 
-	if e.trailers.Len() > 0 {
-		grpc.SetTrailer(e.ctx, e.trailers)
+	trailers := redactMD(e.factory.redactor(), e.trailers)
+	if id := e.ID(); id != "" {
+		trailers.Set(ErrorIDTrailerKey, id)
+	}
+
+	if e.retryAfter != nil {
+		trailers.Set(RetryAfterKey, formatRetryAfter(*e.retryAfter))
+	}
+
+	if trailers.Len() > 0 {
+		grpc.SetTrailer(e.ctx, trailers)
 	}
 
 	return e.GRPCStatus().Err()
 }
 
 func New(e interface{}, opts ...ErrorOption) DetailedError {
+	return defaultFactory.Load().new(e, opts...)
+}
+
+// new must be called directly by `New` and `Factory.New`, the stack is captured relative to them
+func (f *Factory) new(e interface{}, opts ...ErrorOption) DetailedError {
 	var original error
 	var message string
 
@@ -330,12 +693,10 @@ func New(e interface{}, opts ...ErrorOption) DetailedError {
 
 	errOpts := &errorOptions{
 		message:      message,
-		headers:      make(metadata.MD),
-		trailers:     make(metadata.MD),
 		callerOffset: 2,
 		ctx:          context.Background(),
 		internalCode: nil,
-		code:         defaultErrorCode,
+		code:         f.defaultErrorCode(),
 		reportable:   false,
 		skipIfNil:    false,
 	}
@@ -348,26 +709,41 @@ func New(e interface{}, opts ...ErrorOption) DetailedError {
 		return nil
 	}
 
-	callers := make([]uintptr, stackTraceDepth)
-	length := runtime.Callers(errOpts.callerOffset, callers[:])
+	// the code received from another service selects the capture mode of the rehydrated error
+	mode, code := errOpts.stackCapture, errOpts.code
+	if st, ok := status.FromError(original); ok && st != nil {
+		code = codes.Find(int(st.Code()))
+	}
+
+	if mode == nil {
+		m := f.stackCapture(code)
+		mode = &m
+	}
+
+	frames := captureStack(errOpts.callerOffset+1, f.stackTraceDepth(), *mode)
 
-	frames := make([]frame, length)
-	for i, pc := range callers[:length] {
-		frames[i] = frame(pc)
+	id, generated := errOpts.id, false
+	if id == "" {
+		id, generated = f.seedID(errOpts.ctx)
 	}
 
 	de := &err{
+		id:           id,
+		idGenerated:  generated,
+		trace:        f.traceContextFrom(errOpts.ctx),
 		message:      errOpts.message,
+		safeMessage:  errOpts.safeMessage,
 		original:     original,
 		frames:       frames,
 		headers:      errOpts.headers,
 		trailers:     errOpts.trailers,
-		reasons:      make(map[string][]Reason),
 		code:         errOpts.code,
 		reportable:   errOpts.reportable,
+		retryable:    errOpts.retryable,
+		retryAfter:   errOpts.retryAfter,
 		internalCode: errOpts.internalCode,
-		metadata:     make(map[string]interface{}),
 		ctx:          errOpts.ctx,
+		factory:      f,
 	}
 
 	stErr, ok := status.FromError(original)
@@ -375,6 +751,23 @@ func New(e interface{}, opts ...ErrorOption) DetailedError {
 		return de
 	}
 
+	if ids := errOpts.trailers.Get(ErrorIDTrailerKey); len(ids) > 0 && errOpts.id == "" {
+		// the trailer is added again in `Send`
+		de.id, de.idGenerated = ids[0], false
+		de.trailers.Delete(ErrorIDTrailerKey)
+	}
+
+	for _, md := range []metadata.MD{errOpts.headers, errOpts.trailers} {
+		if values := md.Get(RetryAfterKey); len(values) > 0 {
+			// the trailer is added again in `Send`
+			md.Delete(RetryAfterKey)
+
+			if d, ok := parseRetryAfter(values[0]); ok && de.retryAfter == nil {
+				de.RetryAfter(d)
+			}
+		}
+	}
+
 	statusCode := int(stErr.Code())
 	httpHeaderKey := response.GetHttpHeaderKey()
 	if httpStatusCode := errOpts.headers.Get(httpHeaderKey); len(httpStatusCode) > 0 {
@@ -390,35 +783,99 @@ func New(e interface{}, opts ...ErrorOption) DetailedError {
 	// if a message is not provided and message from error is not an empty string, overwrite it
 	if stMsg := stErr.Message(); message == "" && stMsg != "" {
 		de.message = stMsg
+		de.safeMessage = nil
 	}
 
 	if code := codes.Find(statusCode); code.IsError() {
 		de.code = code
 	}
 
+	list := make([]*ErrorDetails, 0)
+	internal := make([]any, 0)
+	hasV2 := false
 	for idx, detail := range stErr.Details() {
-		details, err := errorUnmarshaler(idx, detail)
+		if IsInternalDetails(detail) {
+			internal = append(internal, detail)
+			continue
+		}
+
+		if d, ok := retryInfoFromDetail(detail); ok {
+			if errOpts.retryAfter == nil {
+				de.RetryAfter(d)
+			}
+
+			continue
+		}
+
+		details, err := f.unmarshaler()(idx, detail)
 		if err != nil || details == nil {
 			continue
 		}
 
+		hasV2 = hasV2 || details.Version == DetailsV2
+		list = append(list, details)
+	}
+
+	for _, details := range list {
+		// v1 is only sent alongside v2 for the consumers who don't understand v2 yet
+		if hasV2 && details.Version == DetailsV1 {
+			continue
+		}
+
 		if details.Message != nil {
 			de.message = *details.Message
+			de.safeMessage = nil
+		}
+
+		if details.ID != "" && errOpts.id == "" {
+			de.id, de.idGenerated = details.ID, false
+		}
+
+		// the trace context of the local context takes precedence
+		if tc := (TraceContext{TraceID: details.TraceID, SpanID: details.SpanID}); de.trace == nil && tc.IsValid() {
+			de.trace = &tc
 		}
 
 		if details.InternalCode != nil {
 			de.internalCode = details.InternalCode
 		}
 
-		for k, v := range details.Reasons {
-			de.reasons[k] = append(de.reasons[k], v...)
+		if details.Retryable != nil && errOpts.retryable == nil {
+			de.retryable = details.Retryable
 		}
 
+		// the keys of the field paths are rendered again from their paths, so producers using a different
+		// path style are normalized. The other keys are kept byte for byte.
+		for _, k := range details.OrderedReasonKeys() {
+			path, ok := details.FieldPaths[k]
+			if !ok {
+				de.appendReasons(k, details.Reasons[k]...)
+				continue
+			}
+
+			key := path.Format(f.pathStyle())
+			de.appendReasons(key, details.Reasons[k]...)
+			de.setPath(key, path)
+		}
+
+		de.globalReasons = append(de.globalReasons, details.GlobalReasons...)
+
 		// metadata is always overwritten here, at least now, there is no intention to merge multiple metadata from details
 		for k, v := range details.Metadata {
-			de.metadata[k] = v
+			// the keys of a custom unmarshaler without a visibility are kept internal too
+			visibility, ok := details.MetadataVisibility[k]
+			if !ok {
+				visibility = VisibilityInternal
+			}
+
+			de.AddMetadataWithVisibility(k, v, visibility)
 		}
 	}
 
+	// internal details are decoded last, the public details take precedence over them
+	for _, detail := range internal {
+		de.decodeInternalDetails(detail)
+	}
+
 	return de
 }
@@ -0,0 +1,107 @@
+// Package errorstest helps the tests asserting on the errors of github.com/poorly-written/go-errors
+package errorstest
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/poorly-written/go-errors"
+	"github.com/poorly-written/grpc-http-response/codes"
+)
+
+// detailed fails the test when the error is nil, the other errors are rehydrated with errors.New
+func detailed(t testing.TB, err error) errors.DetailedError {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("expected an error, got nil")
+	}
+
+	return errors.New(err)
+}
+
+func AssertCode(t testing.TB, err error, code codes.Code) {
+	t.Helper()
+
+	if got := detailed(t, err).GetCode(); got != code {
+		t.Errorf("expected code %d, got %d", code.HttpCode(), got.HttpCode())
+	}
+}
+
+func AssertInternalCode(t testing.TB, err error, code string) {
+	t.Helper()
+
+	got, ok := detailed(t, err).GetInternalCode()
+	if !ok {
+		t.Errorf("expected internal code %q, got none", code)
+		return
+	}
+
+	if got != code {
+		t.Errorf("expected internal code %q, got %q", code, got)
+	}
+}
+
+// AssertReason asserts that the key has a reason of the type. e.g. "required", "invalid"
+func AssertReason(t testing.TB, err error, key string, reasonType string) {
+	t.Helper()
+
+	reasons, ok := detailed(t, err).GetReasons()[key]
+	if !ok {
+		t.Errorf("expected a reason for %q, got none", key)
+		return
+	}
+
+	types := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		types = append(types, fmt.Sprintf("%v", r.ToHashMap()["type"]))
+	}
+
+	if !slices.Contains(types, reasonType) {
+		t.Errorf("expected a %q reason for %q, got %v", reasonType, key, types)
+	}
+}
+
+func AssertMetadata(t testing.TB, err error, key string, value any) {
+	t.Helper()
+
+	got, ok := detailed(t, err).GetMetadata()[key]
+	if !ok {
+		t.Errorf("expected metadata %q, got none", key)
+		return
+	}
+
+	if !reflect.DeepEqual(got, value) {
+		t.Errorf("expected metadata %q to be %#v, got %#v", key, value, got)
+	}
+}
+
+// AssertHeader asserts that the header has the value among its values
+func AssertHeader(t testing.TB, err error, key string, value string) {
+	t.Helper()
+
+	values := detailed(t, err).GetHeaders().Get(key)
+	if !slices.Contains(values, value) {
+		t.Errorf("expected header %q to contain %q, got %v", key, value, values)
+	}
+}
+
+// RoundTrip pushes the error through GRPCStatus and back through errors.New, like it's received by a client
+func RoundTrip(t testing.TB, err error, opts ...errors.ErrorOption) errors.DetailedError {
+	t.Helper()
+
+	return errors.New(detailed(t, err).GRPCStatus().Err(), opts...)
+}
+
+// Override replaces the configuration of the package-level errors.New for the test,
+// it's restored on t.Cleanup. The tests using it must not run in parallel.
+// Every setting with a FactoryOption can be overridden, the rest keep the package-level configuration.
+//
+//	errorstest.Override(t, errors.WithRedactor(errors.NoopRedactor), errors.WithDetailsVersion(errors.DetailsV2))
+func Override(t testing.TB, opts ...errors.FactoryOption) {
+	t.Helper()
+
+	t.Cleanup(errors.ReplaceDefaultFactory(errors.NewFactory(opts...)))
+}
@@ -0,0 +1,34 @@
+package errorstest_test
+
+import (
+	"testing"
+
+	"github.com/poorly-written/go-errors"
+	"github.com/poorly-written/go-errors/errorstest"
+	"github.com/poorly-written/grpc-http-response/codes"
+)
+
+func TestOverride(t *testing.T) {
+	t.Run("overridden", func(t *testing.T) {
+		errorstest.Override(t,
+			errors.WithDefaultErrorCode(codes.InternalServerError),
+			errors.WithRedactor(errors.NoopRedactor),
+			errors.WithDetailsVersion(errors.DetailsV2),
+		)
+
+		de := errors.New(nil, errors.Message("mail bob@example.com"))
+		errorstest.AssertCode(t, de, codes.InternalServerError)
+
+		st := de.GRPCStatus()
+		if st.Message() != "mail bob@example.com" || len(st.Details()) != 1 {
+			t.Errorf("expected the redactor and the details version of the override, got %q with %d details", st.Message(), len(st.Details()))
+		}
+	})
+
+	de := errors.New(nil, errors.Message("mail bob@example.com"))
+	errorstest.AssertCode(t, de, codes.BadRequest)
+
+	if st := de.GRPCStatus(); st.Message() == "mail bob@example.com" || len(st.Details()) != 2 {
+		t.Errorf("expected the configuration to be restored, got %q with %d details", st.Message(), len(st.Details()))
+	}
+}
@@ -0,0 +1,169 @@
+package errorstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/poorly-written/go-errors"
+)
+
+// update is namespaced, so it doesn't collide with the -update flags of the other packages
+var update = flag.Bool("errorstest.update", false, "update the golden files of errorstest.Golden")
+
+type goldenConfig struct {
+	maskLines bool
+	noFrames  bool
+	dir       string
+}
+
+type GoldenOption func(*goldenConfig)
+
+// MaskLines replaces the line numbers of the frames, so the snapshots don't change when the code moves
+func MaskLines() GoldenOption {
+	return func(c *goldenConfig) {
+		c.maskLines = true
+	}
+}
+
+func WithoutFrames() GoldenOption {
+	return func(c *goldenConfig) {
+		c.noFrames = true
+	}
+}
+
+// GoldenDir sets the directory of the golden files, it defaults to "testdata"
+func GoldenDir(dir string) GoldenOption {
+	return func(c *goldenConfig) {
+		c.dir = dir
+	}
+}
+
+// Golden compares the deterministic JSON rendering of the error with testdata/<test name>.golden.json,
+// the file is written instead when the tests run with -errorstest.update.
+// The ID and the trace context are left out, they're different on every run.
+func Golden(t testing.TB, err error, opts ...GoldenOption) {
+	t.Helper()
+
+	c := &goldenConfig{
+		dir: "testdata",
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	got, e := render(detailed(t, err), c)
+	if e != nil {
+		t.Fatalf("failed to render the error: %v", e)
+	}
+
+	path := filepath.Join(c.dir, strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())+".golden.json")
+
+	if *update {
+		if e := os.MkdirAll(c.dir, 0o755); e != nil {
+			t.Fatalf("failed to create %s: %v", c.dir, e)
+		}
+
+		if e := os.WriteFile(path, got, 0o644); e != nil {
+			t.Fatalf("failed to write %s: %v", path, e)
+		}
+
+		return
+	}
+
+	want, e := os.ReadFile(path)
+	if e != nil {
+		t.Fatalf("failed to read %s, run the tests with -errorstest.update to create it: %v", path, e)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("the error doesn't match %s, run the tests with -errorstest.update if the change is expected\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+func render(d errors.DetailedError, c *goldenConfig) ([]byte, error) {
+	out := map[string]any{
+		"message": d.Error(),
+		"code":    d.GetCode().HttpCode(),
+	}
+
+	if code, ok := d.GetInternalCode(); ok {
+		out["internal_code"] = code
+	}
+
+	if d.IsRetryable() {
+		out["retryable"] = true
+	}
+
+	if delay, ok := d.GetRetryAfter(); ok {
+		out["retry_after"] = delay.String()
+	}
+
+	if d.IsReportable() {
+		out["reportable"] = true
+	}
+
+	if global := d.GetGlobalReasons(); len(global) > 0 {
+		list := make([]any, len(global))
+		for i, r := range global {
+			list[i] = r.ToHashMap()
+		}
+
+		out["global_reasons"] = list
+	}
+
+	// the keys are sorted, so the snapshot doesn't depend on the order the reasons were added in
+	if reasons := d.GetReasons(); len(reasons) > 0 {
+		keyed := make(map[string]any, len(reasons))
+		for key, list := range reasons {
+			maps := make([]any, len(list))
+			for i, r := range list {
+				maps[i] = r.ToHashMap()
+			}
+
+			keyed[key] = maps
+		}
+
+		out["reasons"] = keyed
+	}
+
+	if md := d.GetMetadata(); len(md) > 0 {
+		out["metadata"] = md
+	}
+
+	if headers := d.GetHeaders(); headers.Len() > 0 {
+		out["headers"] = headers
+	}
+
+	if trailers := d.GetTrailers(); trailers.Len() > 0 {
+		out["trailers"] = trailers
+	}
+
+	if !c.noFrames {
+		frames := make([]string, 0)
+		for _, f := range d.StackTrace() {
+			line := fmt.Sprintf("%d", f.Line)
+			if c.maskLines {
+				line = "*"
+			}
+
+			frames = append(frames, fmt.Sprintf("%s.%s %s:%s", f.Package, f.Name, filepath.Base(f.File), line))
+		}
+
+		out["frames"] = frames
+	}
+
+	// json sorts the keys of the maps
+	rendered, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(rendered, '\n'), nil
+}
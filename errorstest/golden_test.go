@@ -0,0 +1,40 @@
+package errorstest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/poorly-written/go-errors"
+	"github.com/poorly-written/go-errors/errorstest"
+	"github.com/poorly-written/grpc-http-response/codes"
+)
+
+func TestGolden(t *testing.T) {
+	info := "the name is taken"
+	de := errors.New(nil,
+		errors.Message("invalid request"),
+		errors.ErrorCode(codes.Conflict),
+		errors.InternalCode("name_taken"),
+		errors.RetryAfter(time.Second),
+	).
+		AddFieldReason(errors.Path("user", "name"), errors.NewReason("exists", &info, nil)).
+		AddReason("email", errors.SimpleReason("required")).
+		AddGlobalReason(errors.SimpleReason("invalid")).
+		AddMetadata("tenant", "acme").
+		AddHeader("x-request-cost", "3")
+
+	errorstest.Golden(t, de, errorstest.WithoutFrames())
+}
+
+func TestGoldenRoundTrip(t *testing.T) {
+	de := errors.New(nil, errors.Message("not found"), errors.ErrorCode(codes.NotFound)).
+		AddReason("id", errors.SimpleReason("missing"))
+
+	errorstest.Golden(t, errorstest.RoundTrip(t, de), errorstest.WithoutFrames())
+}
+
+func TestGoldenMaskLines(t *testing.T) {
+	de := errors.New(nil, errors.Message("caller"), errors.CaptureStack(errors.CaptureCaller))
+
+	errorstest.Golden(t, de, errorstest.MaskLines())
+}
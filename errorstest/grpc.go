@@ -0,0 +1,90 @@
+package errorstest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/poorly-written/go-errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// HarnessMethod is the method called by Observe, every method is handled by the harness
+const HarnessMethod = "/errorstest.Harness/Call"
+
+// Observed is what the client of the harness received
+type Observed struct {
+	Status   *status.Status
+	Details  []any
+	Headers  metadata.MD
+	Trailers metadata.MD
+	// Error is the error rehydrated with errors.New from the status, the headers and the trailers
+	Error errors.DetailedError
+}
+
+// Observe starts an in-process gRPC server and client connected with bufconn, calls the handler for a request
+// and returns what the client observed. The server and the client are stopped on t.Cleanup.
+//
+//	observed := errorstest.Observe(t, func(ctx context.Context) error {
+//		return errors.New(nil, errors.Context(ctx)).AddHeader("x-key", "value").Send()
+//	})
+func Observe(t testing.TB, handler func(ctx context.Context) error, opts ...grpc.ServerOption) *Observed {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer(append(opts, grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+			return err
+		}
+
+		return handler(stream.Context())
+	}))...)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	observed := &Observed{}
+	err = conn.Invoke(context.Background(), HarnessMethod, &emptypb.Empty{}, &emptypb.Empty{},
+		grpc.Header(&observed.Headers),
+		grpc.Trailer(&observed.Trailers),
+	)
+
+	observed.Status = status.Convert(err)
+	observed.Details = observed.Status.Details()
+
+	if err != nil {
+		observed.Error = errors.New(err, errors.Headers(observed.Headers.Copy()), errors.Trailers(observed.Trailers.Copy()))
+	}
+
+	return observed
+}
+
+// ObserveError sends the error with the context of the request, like a handler returning `err.Context(ctx).Send()`
+func ObserveError(t testing.TB, err errors.DetailedError, opts ...grpc.ServerOption) *Observed {
+	t.Helper()
+
+	return Observe(t, func(ctx context.Context) error {
+		return err.Context(ctx).Send()
+	}, opts...)
+}
@@ -0,0 +1,72 @@
+package errorstest_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/poorly-written/go-errors"
+	"github.com/poorly-written/go-errors/errorstest"
+	"github.com/poorly-written/grpc-http-response/codes"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+)
+
+func TestObserveError(t *testing.T) {
+	de := errors.New(nil, errors.Message("name is required"), errors.ErrorCode(codes.TooManyRequests)).
+		AddReason("name", errors.SimpleReason("required")).
+		AddHeader("x-request-cost", "3").
+		AddTrailer("x-quota", "exhausted").
+		RetryAfter(5 * time.Second)
+
+	observed := errorstest.ObserveError(t, de)
+
+	if observed.Status.Code() != codes.TooManyRequests.GrpcCode() || observed.Status.Message() != "name is required" {
+		t.Errorf("unexpected status %s %q", observed.Status.Code(), observed.Status.Message())
+	}
+
+	if len(observed.Details) == 0 {
+		t.Errorf("expected the details on the wire")
+	}
+
+	if got := observed.Headers.Get("x-request-cost"); len(got) != 1 || got[0] != "3" {
+		t.Errorf("expected the header, got %v", observed.Headers)
+	}
+
+	if got := observed.Trailers.Get(errors.ErrorIDTrailerKey); len(got) != 1 || got[0] != de.ID() {
+		t.Errorf("expected the error ID trailer %q, got %v", de.ID(), got)
+	}
+
+	if got := observed.Trailers.Get(errors.RetryAfterKey); len(got) != 1 || got[0] != "5" {
+		t.Errorf("expected the retry-after trailer, got %v", got)
+	}
+
+	errorstest.AssertCode(t, observed.Error, codes.TooManyRequests)
+	errorstest.AssertReason(t, observed.Error, "name", "required")
+	errorstest.AssertHeader(t, observed.Error, "x-request-cost", "3")
+
+	if got := observed.Error.GetTrailers().Get("x-quota"); len(got) != 1 || got[0] != "exhausted" {
+		t.Errorf("expected the rehydrated trailer, got %v", got)
+	}
+
+	if observed.Error.ID() != de.ID() {
+		t.Errorf("expected the rehydrated error to keep the ID %q, got %q", de.ID(), observed.Error.ID())
+	}
+
+	if d, ok := observed.Error.GetRetryAfter(); !ok || d != 5*time.Second {
+		t.Errorf("expected the rehydrated retry-after, got %v", d)
+	}
+}
+
+func TestObserveWithServerOptions(t *testing.T) {
+	policy := errors.DefaultBoundaryPolicy()
+
+	observed := errorstest.Observe(t, func(context.Context) error {
+		return stderrors.New("dial tcp 10.0.0.7:5432: connection refused")
+	}, grpc.StreamInterceptor(policy.StreamServerInterceptor()))
+
+	if observed.Status.Code() != grpccodes.Unknown || observed.Status.Message() != "internal server error" {
+		t.Errorf("expected the sanitized plain error, got %s %q", observed.Status.Code(), observed.Status.Message())
+	}
+}
@@ -0,0 +1,166 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	traceparentKey = "traceparent"
+	tracestateKey  = "tracestate"
+)
+
+// ChainExtractors combines the extractors, when the extractors return the same key the last one wins.
+//
+//	errors.SetContextualMetadataExtractor(errors.ChainExtractors(
+//		errors.MethodExtractor(),
+//		errors.PeerExtractor(),
+//		errors.IncomingMetadataExtractor("x-tenant-id"),
+//	))
+func ChainExtractors(extractors ...contextualMetadataExtractorFunc) contextualMetadataExtractorFunc {
+	return func(ctx context.Context) map[string]interface{} {
+		md := make(map[string]interface{})
+		for _, extractor := range extractors {
+			if extractor == nil {
+				continue
+			}
+
+			for k, v := range extractor(ctx) {
+				md[k] = v
+			}
+		}
+
+		return md
+	}
+}
+
+// IncomingMetadataExtractor extracts the allowed keys of the incoming gRPC metadata.
+// Keys with a single value are extracted as a string, otherwise as a string slice.
+func IncomingMetadataExtractor(keys ...string) contextualMetadataExtractorFunc {
+	return func(ctx context.Context) map[string]interface{} {
+		incoming, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil
+		}
+
+		md := make(map[string]interface{})
+		for _, key := range keys {
+			values := incoming.Get(key)
+			switch len(values) {
+			case 0:
+			case 1:
+				md[key] = values[0]
+			default:
+				md[key] = values
+			}
+		}
+
+		return md
+	}
+}
+
+// PeerExtractor extracts the address of the client as "peer.address"
+func PeerExtractor() contextualMetadataExtractorFunc {
+	return func(ctx context.Context) map[string]interface{} {
+		p, ok := peer.FromContext(ctx)
+		if !ok || p.Addr == nil {
+			return nil
+		}
+
+		return map[string]interface{}{
+			"peer.address": p.Addr.String(),
+		}
+	}
+}
+
+// MethodExtractor extracts the full gRPC method name as "grpc.method"
+func MethodExtractor() contextualMetadataExtractorFunc {
+	return func(ctx context.Context) map[string]interface{} {
+		method, ok := grpc.Method(ctx)
+		if !ok {
+			return nil
+		}
+
+		return map[string]interface{}{
+			"grpc.method": method,
+		}
+	}
+}
+
+// TraceContextExtractor extracts the W3C "traceparent" and "tracestate" from the incoming gRPC metadata,
+// or from the headers of the HTTP request stored with ContextWithHTTPRequest
+func TraceContextExtractor() contextualMetadataExtractorFunc {
+	return func(ctx context.Context) map[string]interface{} {
+		md := make(map[string]interface{})
+		for _, key := range []string{traceparentKey, tracestateKey} {
+			if value := incomingValue(ctx, key); value != "" {
+				md[key] = value
+			}
+		}
+
+		return md
+	}
+}
+
+// HTTPRequestIDExtractor extracts the request ID header of the HTTP request stored with ContextWithHTTPRequest
+// as "request_id", the header defaults to "X-Request-Id"
+func HTTPRequestIDExtractor(header ...string) contextualMetadataExtractorFunc {
+	key := "X-Request-Id"
+	if len(header) > 0 && header[0] != "" {
+		key = header[0]
+	}
+
+	return func(ctx context.Context) map[string]interface{} {
+		r, ok := HTTPRequestFromContext(ctx)
+		if !ok {
+			return nil
+		}
+
+		id := r.Header.Get(key)
+		if id == "" {
+			return nil
+		}
+
+		return map[string]interface{}{
+			"request_id": id,
+		}
+	}
+}
+
+type httpRequestContextKey struct{}
+
+func ContextWithHTTPRequest(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, httpRequestContextKey{}, r)
+}
+
+func HTTPRequestFromContext(ctx context.Context) (*http.Request, bool) {
+	r, ok := ctx.Value(httpRequestContextKey{}).(*http.Request)
+
+	return r, ok && r != nil
+}
+
+// HTTPRequestMiddleware stores the request in its context, so the extractors can read it
+func HTTPRequestMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(ContextWithHTTPRequest(r.Context(), r)))
+	})
+}
+
+// incomingValue reads the key from the incoming gRPC metadata, falling back to the stored HTTP request headers
+func incomingValue(ctx context.Context, key string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	if r, ok := HTTPRequestFromContext(ctx); ok {
+		return r.Header.Get(key)
+	}
+
+	return ""
+}
@@ -0,0 +1,144 @@
+package errors
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func staticExtractor(md map[string]interface{}) contextualMetadataExtractorFunc {
+	return func(context.Context) map[string]interface{} {
+		return md
+	}
+}
+
+func TestExtractors(t *testing.T) {
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.MD{
+		"x-tenant-id":   {"acme"},
+		"x-forwarded":   {"a", "b"},
+		traceparentKey:  {traceparent},
+		tracestateKey:   {"vendor=1"},
+		"x-not-allowed": {"secret"},
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-Id", "req-1")
+	req.Header.Set("X-Correlation-Id", "corr-1")
+	req.Header.Set("Traceparent", traceparent)
+
+	tests := []struct {
+		name      string
+		extractor contextualMetadataExtractorFunc
+		ctx       context.Context
+		want      map[string]interface{}
+	}{
+		{
+			name: "chain keeps the last value",
+			extractor: ChainExtractors(
+				staticExtractor(map[string]interface{}{"a": 1, "b": 1}),
+				nil,
+				staticExtractor(map[string]interface{}{"b": 2}),
+			),
+			ctx:  context.Background(),
+			want: map[string]interface{}{"a": 1, "b": 2},
+		},
+		{
+			name:      "incoming metadata",
+			extractor: IncomingMetadataExtractor("x-tenant-id", "x-forwarded", "x-missing"),
+			ctx:       incoming,
+			want:      map[string]interface{}{"x-tenant-id": "acme", "x-forwarded": []string{"a", "b"}},
+		},
+		{
+			name:      "incoming metadata without metadata",
+			extractor: IncomingMetadataExtractor("x-tenant-id"),
+			ctx:       context.Background(),
+		},
+		{
+			name:      "peer",
+			extractor: PeerExtractor(),
+			ctx:       peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}}),
+			want:      map[string]interface{}{"peer.address": "10.0.0.1:5000"},
+		},
+		{
+			name:      "peer without peer",
+			extractor: PeerExtractor(),
+			ctx:       context.Background(),
+		},
+		{
+			name:      "method",
+			extractor: MethodExtractor(),
+			ctx:       grpc.NewContextWithServerTransportStream(context.Background(), &recordingStream{}),
+			want:      map[string]interface{}{"grpc.method": "/test.Service/Method"},
+		},
+		{
+			name:      "method without stream",
+			extractor: MethodExtractor(),
+			ctx:       context.Background(),
+		},
+		{
+			name:      "trace context from the metadata",
+			extractor: TraceContextExtractor(),
+			ctx:       incoming,
+			want:      map[string]interface{}{traceparentKey: traceparent, tracestateKey: "vendor=1"},
+		},
+		{
+			name:      "trace context from the request",
+			extractor: TraceContextExtractor(),
+			ctx:       ContextWithHTTPRequest(context.Background(), req),
+			want:      map[string]interface{}{traceparentKey: traceparent},
+		},
+		{
+			name:      "HTTP request ID",
+			extractor: HTTPRequestIDExtractor(),
+			ctx:       ContextWithHTTPRequest(context.Background(), req),
+			want:      map[string]interface{}{"request_id": "req-1"},
+		},
+		{
+			name:      "HTTP request ID from another header",
+			extractor: HTTPRequestIDExtractor("X-Correlation-Id"),
+			ctx:       ContextWithHTTPRequest(context.Background(), req),
+			want:      map[string]interface{}{"request_id": "corr-1"},
+		},
+		{
+			name:      "HTTP request ID without request",
+			extractor: HTTPRequestIDExtractor(),
+			ctx:       context.Background(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.extractor(tt.ctx)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHTTPRequestMiddleware(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-Id", "req-1")
+
+	var got map[string]interface{}
+	HTTPRequestMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = HTTPRequestIDExtractor()(r.Context())
+	})).ServeHTTP(httptest.NewRecorder(), req)
+
+	if got["request_id"] != "req-1" {
+		t.Errorf("expected the request to be stored in its context, got %v", got)
+	}
+}
@@ -0,0 +1,387 @@
+package errors
+
+import (
+	"sync/atomic"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+)
+
+type factoryConfig struct {
+	stackTraceDepth    *int
+	defaultErrorCode   *codes.Code
+	marshaler          errorMarshalerFunc
+	unmarshaler        errorUnmarshalerFunc
+	extractor          contextualMetadataExtractorFunc
+	redactor           Redactor
+	detailsVersion     *DetailsVersion
+	pathStyle          *PathStyle
+	metadataPolicy     *MetadataPolicy
+	stackCapture       *StackCaptureMode
+	stackCaptureByCode map[codes.Code]StackCaptureMode
+	stackOptions       *stackConfig
+	idGenerator        idGeneratorFunc
+	retryableCodes     map[codes.Code]bool
+	internalKey        *InternalDetailsKey
+	metadataWarner     metadataWarnerFunc
+	timeFormat         *string
+	defaultVisibility  *Visibility
+	requestIDKey       *string
+	traceProvider      TraceContextProvider
+}
+
+type FactoryOption func(*factoryConfig)
+
+func WithStackTraceDepth(depth int) FactoryOption {
+	return func(c *factoryConfig) {
+		if depth > 0 {
+			c.stackTraceDepth = &depth
+		}
+	}
+}
+
+func WithDefaultErrorCode(code codes.Code) FactoryOption {
+	return func(c *factoryConfig) {
+		c.defaultErrorCode = &code
+	}
+}
+
+func WithErrorMarshaler(marshaler errorMarshalerFunc) FactoryOption {
+	return func(c *factoryConfig) {
+		c.marshaler = marshaler
+	}
+}
+
+func WithErrorUnmarshaler(unmarshaler errorUnmarshalerFunc) FactoryOption {
+	return func(c *factoryConfig) {
+		c.unmarshaler = unmarshaler
+	}
+}
+
+func WithContextualMetadataExtractor(extractor contextualMetadataExtractorFunc) FactoryOption {
+	return func(c *factoryConfig) {
+		c.extractor = extractor
+	}
+}
+
+func WithRedactor(r Redactor) FactoryOption {
+	return func(c *factoryConfig) {
+		c.redactor = r
+	}
+}
+
+func WithDetailsVersion(version DetailsVersion) FactoryOption {
+	return func(c *factoryConfig) {
+		c.detailsVersion = &version
+	}
+}
+
+func WithPathStyle(style PathStyle) FactoryOption {
+	return func(c *factoryConfig) {
+		c.pathStyle = &style
+	}
+}
+
+func WithMetadataPolicy(policy MetadataPolicy) FactoryOption {
+	return func(c *factoryConfig) {
+		c.metadataPolicy = &policy
+	}
+}
+
+// WithStackCapture sets the default capture mode of the factory
+func WithStackCapture(mode StackCaptureMode) FactoryOption {
+	return func(c *factoryConfig) {
+		c.stackCapture = &mode
+	}
+}
+
+// WithStackCaptureByCode overrides the capture mode for the codes selected with ErrorCode, like SetStackCaptureByCode
+func WithStackCaptureByCode(modes map[codes.Code]StackCaptureMode) FactoryOption {
+	return func(c *factoryConfig) {
+		c.stackCaptureByCode = make(map[codes.Code]StackCaptureMode, len(modes))
+		for code, mode := range modes {
+			c.stackCaptureByCode[code] = mode
+		}
+	}
+}
+
+func WithStackOptions(opts ...StackOption) FactoryOption {
+	return func(c *factoryConfig) {
+		c.stackOptions = newStackConfig(opts...)
+	}
+}
+
+func WithIDGenerator(generator idGeneratorFunc) FactoryOption {
+	return func(c *factoryConfig) {
+		c.idGenerator = generator
+	}
+}
+
+// WithRetryableCodes replaces the retryability table, like SetRetryableCodes
+func WithRetryableCodes(list ...codes.Code) FactoryOption {
+	return func(c *factoryConfig) {
+		c.retryableCodes = make(map[codes.Code]bool, len(list))
+		for _, code := range list {
+			c.retryableCodes[code] = true
+		}
+	}
+}
+
+// WithInternalDetailsKey enables the internal details, like SetInternalDetailsKey
+func WithInternalDetailsKey(key *InternalDetailsKey) FactoryOption {
+	return func(c *factoryConfig) {
+		c.internalKey = key
+	}
+}
+
+// WithMetadataWarner receives the metadata dropped by the factory's errors, like SetMetadataWarner
+func WithMetadataWarner(warner metadataWarnerFunc) FactoryOption {
+	return func(c *factoryConfig) {
+		c.metadataWarner = warner
+	}
+}
+
+// WithTimeFormat sets the layout of the time.Time metadata, like SetTimeFormat
+func WithTimeFormat(layout string) FactoryOption {
+	return func(c *factoryConfig) {
+		c.timeFormat = &layout
+	}
+}
+
+// WithDefaultMetadataVisibility sets the visibility of the metadata added without an explicit one, like SetDefaultMetadataVisibility
+func WithDefaultMetadataVisibility(visibility Visibility) FactoryOption {
+	return func(c *factoryConfig) {
+		c.defaultVisibility = &visibility
+	}
+}
+
+// WithRequestIDMetadataKey seeds the error IDs from the incoming gRPC metadata key, like SetRequestIDMetadataKey
+func WithRequestIDMetadataKey(key string) FactoryOption {
+	return func(c *factoryConfig) {
+		c.requestIDKey = &key
+	}
+}
+
+// WithTraceContextProvider sets the provider of the trace context, like SetTraceContextProvider
+func WithTraceContextProvider(provider TraceContextProvider) FactoryOption {
+	return func(c *factoryConfig) {
+		c.traceProvider = provider
+	}
+}
+
+// Factory creates errors with its own configuration, so the libraries and the tests don't share the globals.
+// The configuration it doesn't set falls back to the package-level setters.
+//
+//	var apiErrors = errors.NewFactory(errors.WithDefaultErrorCode(codes.InternalServerError))
+//
+//	err := apiErrors.New(e, errors.InternalCode("E1001"))
+type Factory struct {
+	config factoryConfig
+}
+
+func NewFactory(opts ...FactoryOption) *Factory {
+	f := &Factory{}
+	for _, opt := range opts {
+		opt(&f.config)
+	}
+
+	return f
+}
+
+// defaultFactory only uses the package-level configuration unless it is replaced, the package-level `New` delegates to it.
+// It's swapped atomically, so replacing it doesn't race with the errors created concurrently.
+var defaultFactory atomic.Pointer[Factory]
+
+func init() {
+	defaultFactory.Store(NewFactory())
+}
+
+// New creates an error like the package-level `New`, the errors remember the factory,
+// so `GRPCStatus` and `Context` use its marshaler and extractor too
+func (f *Factory) New(e interface{}, opts ...ErrorOption) DetailedError {
+	return f.new(e, opts...)
+}
+
+// ReplaceDefaultFactory makes the package-level `New` and the errors created without a factory use the factory
+// until restore is called. It's meant for the tests, which can't reset the package-level setters.
+func ReplaceDefaultFactory(f *Factory) (restore func()) {
+	if f == nil {
+		return func() {}
+	}
+
+	previous := defaultFactory.Swap(f)
+
+	return func() {
+		defaultFactory.Store(previous)
+	}
+}
+
+// orDefault makes the accessors nil safe, the errors created without a factory use the default one
+func (f *Factory) orDefault() *Factory {
+	if f == nil {
+		return defaultFactory.Load()
+	}
+
+	return f
+}
+
+func (f *Factory) stackTraceDepth() int {
+	if f = f.orDefault(); f.config.stackTraceDepth == nil {
+		return stackTraceDepth
+	}
+
+	return *f.config.stackTraceDepth
+}
+
+func (f *Factory) defaultErrorCode() codes.Code {
+	if f = f.orDefault(); f.config.defaultErrorCode == nil {
+		return defaultErrorCode
+	}
+
+	return *f.config.defaultErrorCode
+}
+
+func (f *Factory) marshaler() errorMarshalerFunc {
+	if f = f.orDefault(); f.config.marshaler == nil {
+		return errorMarshaler
+	}
+
+	return f.config.marshaler
+}
+
+func (f *Factory) unmarshaler() errorUnmarshalerFunc {
+	if f = f.orDefault(); f.config.unmarshaler == nil {
+		return errorUnmarshaler
+	}
+
+	return f.config.unmarshaler
+}
+
+func (f *Factory) extractor() contextualMetadataExtractorFunc {
+	if f = f.orDefault(); f.config.extractor == nil {
+		return contextualMetadataExtractor
+	}
+
+	return f.config.extractor
+}
+
+func (f *Factory) redactor() Redactor {
+	if f = f.orDefault(); f.config.redactor == nil {
+		return activeRedactor
+	}
+
+	return f.config.redactor
+}
+
+func (f *Factory) detailsVersion() DetailsVersion {
+	if f = f.orDefault(); f.config.detailsVersion == nil {
+		return detailsVersion
+	}
+
+	return *f.config.detailsVersion
+}
+
+func (f *Factory) pathStyle() PathStyle {
+	if f = f.orDefault(); f.config.pathStyle == nil {
+		return pathStyle
+	}
+
+	return *f.config.pathStyle
+}
+
+func (f *Factory) metadataPolicy() MetadataPolicy {
+	if f = f.orDefault(); f.config.metadataPolicy == nil {
+		return metadataPolicy
+	}
+
+	return *f.config.metadataPolicy
+}
+
+func (f *Factory) stackCapture(code codes.Code) StackCaptureMode {
+	f = f.orDefault()
+
+	if f.config.stackCaptureByCode != nil {
+		if mode, ok := f.config.stackCaptureByCode[code]; ok {
+			return mode
+		}
+	} else if mode, ok := stackCaptureByCode[code]; ok {
+		return mode
+	}
+
+	if f.config.stackCapture == nil {
+		return defaultStackCapture
+	}
+
+	return *f.config.stackCapture
+}
+
+func (f *Factory) stackOptions() *stackConfig {
+	if f = f.orDefault(); f.config.stackOptions == nil {
+		return stackOptions
+	}
+
+	return f.config.stackOptions
+}
+
+func (f *Factory) idGenerator() idGeneratorFunc {
+	if f = f.orDefault(); f.config.idGenerator == nil {
+		return idGenerator
+	}
+
+	return f.config.idGenerator
+}
+
+func (f *Factory) isRetryableCode(code codes.Code) bool {
+	if f = f.orDefault(); f.config.retryableCodes == nil {
+		return retryableCodes[code]
+	}
+
+	return f.config.retryableCodes[code]
+}
+
+func (f *Factory) internalDetailsKey() *InternalDetailsKey {
+	if f = f.orDefault(); f.config.internalKey == nil {
+		return internalKey
+	}
+
+	return f.config.internalKey
+}
+
+func (f *Factory) metadataWarner() metadataWarnerFunc {
+	if f = f.orDefault(); f.config.metadataWarner == nil {
+		return metadataWarner
+	}
+
+	return f.config.metadataWarner
+}
+
+func (f *Factory) timeFormat() string {
+	if f = f.orDefault(); f.config.timeFormat == nil {
+		return timeFormat
+	}
+
+	return *f.config.timeFormat
+}
+
+func (f *Factory) defaultMetadataVisibility() Visibility {
+	if f = f.orDefault(); f.config.defaultVisibility == nil {
+		return defaultMetadataVisibility
+	}
+
+	return *f.config.defaultVisibility
+}
+
+func (f *Factory) requestIDMetadataKey() string {
+	if f = f.orDefault(); f.config.requestIDKey == nil {
+		return requestIDMetadataKey
+	}
+
+	return *f.config.requestIDKey
+}
+
+func (f *Factory) traceContextProvider() TraceContextProvider {
+	if f = f.orDefault(); f.config.traceProvider == nil {
+		return traceContextProvider
+	}
+
+	return f.config.traceProvider
+}
@@ -0,0 +1,188 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestFactoryConfiguration(t *testing.T) {
+	f := NewFactory(
+		WithDefaultErrorCode(codes.InternalServerError),
+		WithRedactor(NoopRedactor),
+		WithDetailsVersion(DetailsV2),
+		WithPathStyle(JSONPointerPathStyle),
+		WithStackCapture(CaptureNoStack),
+		WithIDGenerator(func(context.Context) string { return "fixed" }),
+		WithRetryableCodes(codes.InternalServerError),
+		WithContextualMetadataExtractor(func(context.Context) map[string]interface{} {
+			return map[string]interface{}{"tenant": "acme"}
+		}),
+	)
+
+	de := f.New(nil, Message("mail bob@example.com")).
+		AddFieldReason(Path("items").Index(0), SimpleReason("required")).
+		Context(context.Background(), true)
+
+	if de.GetCode() != codes.InternalServerError {
+		t.Errorf("expected the default code of the factory, got %d", de.GetCode().HttpCode())
+	}
+
+	if de.ID() != "fixed" {
+		t.Errorf("expected the ID of the generator, got %q", de.ID())
+	}
+
+	if len(de.StackFrames()) != 0 {
+		t.Errorf("expected no frames, got %d", len(de.StackFrames()))
+	}
+
+	if !de.HasReasons("/items/0") {
+		t.Errorf("expected the JSON pointer key, got %v", de.GetReasons())
+	}
+
+	if !de.IsRetryable() {
+		t.Errorf("expected the code to be retryable with the table of the factory")
+	}
+
+	if !de.HasMetadata("tenant") {
+		t.Errorf("expected the metadata of the extractor, got %v", de.GetMetadata())
+	}
+
+	st := de.GRPCStatus()
+	if st.Message() != "mail bob@example.com" {
+		t.Errorf("expected the message to be left by the noop redactor, got %q", st.Message())
+	}
+
+	if len(st.Details()) != 1 {
+		t.Errorf("expected only the v2 details, got %d", len(st.Details()))
+	}
+
+	// the package-level configuration isn't affected
+	if plain := New(nil); plain.GetCode() == codes.InternalServerError || plain.ID() == "fixed" || len(plain.StackFrames()) == 0 {
+		t.Errorf("expected the package-level New to keep the default configuration")
+	}
+}
+
+func TestFactoryStackCaptureByCode(t *testing.T) {
+	f := NewFactory(WithStackCaptureByCode(map[codes.Code]StackCaptureMode{
+		codes.Find(http.StatusNotFound): CaptureCaller,
+	}))
+
+	if frames := f.New(nil, ErrorCode(codes.Find(http.StatusNotFound))).StackFrames(); len(frames) != 1 {
+		t.Errorf("expected the caller frame, got %d frames", len(frames))
+	}
+
+	if frames := f.New(nil).StackFrames(); len(frames) < 2 {
+		t.Errorf("expected the full stack, got %d frames", len(frames))
+	}
+}
+
+func TestFactoryMetadataConfiguration(t *testing.T) {
+	var warned []string
+	f := NewFactory(
+		WithMetadataWarner(func(key string, _ interface{}, _ error) {
+			warned = append(warned, key)
+		}),
+		WithTimeFormat(time.DateOnly),
+		WithDefaultMetadataVisibility(VisibilityPublic),
+	)
+
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	de := f.New(nil).
+		AddMetadata("at", at).
+		AddMetadata("invalid", "\xff")
+
+	if de.MetadataVisibility("at") != VisibilityPublic {
+		t.Errorf("expected the default visibility of the factory, got %d", de.MetadataVisibility("at"))
+	}
+
+	received := New(de.GRPCStatus().Err())
+	if got := received.GetMetadata()["at"]; got != "2026-10-18" {
+		t.Errorf("expected the time format of the factory, got %v", got)
+	}
+
+	if len(warned) != 1 || warned[0] != "invalid" {
+		t.Errorf("expected the invalid metadata to be reported to the warner of the factory, got %v", warned)
+	}
+}
+
+func TestFactoryContextConfiguration(t *testing.T) {
+	tc := TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}
+	f := NewFactory(
+		WithRequestIDMetadataKey("x-request-id"),
+		WithTraceContextProvider(TraceContextProviderFunc(func(context.Context) (TraceContext, bool) {
+			return tc, true
+		})),
+	)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+
+	de := f.New(nil, Context(ctx))
+	if de.ID() != "req-1" {
+		t.Errorf("expected the ID to be seeded from the request, got %q", de.ID())
+	}
+
+	if got, ok := de.TraceContext(); !ok || got != tc {
+		t.Errorf("expected the trace context of the provider, got %+v", got)
+	}
+
+	if late := f.New(nil).Context(ctx); late.ID() != "req-1" {
+		t.Errorf("expected the ID to be seeded by Context, got %q", late.ID())
+	}
+
+	if plain := New(nil, Context(ctx)); plain.ID() == "req-1" {
+		t.Errorf("expected the package-level New to ignore the request ID key of the factory")
+	}
+}
+
+func TestDefaultFactoryConfiguration(t *testing.T) {
+	defer ReplaceDefaultFactory(NewFactory(WithPathStyle(JSONPointerPathStyle), WithStackTraceDepth(10)))()
+
+	if got := Path("items").Index(0).String(); got != "/items/0" {
+		t.Errorf("expected the path style of the default factory, got %q", got)
+	}
+
+	var err error
+	func() {
+		defer Recover(&err)
+		panicDeeply(30)
+	}()
+
+	de := New(err)
+	if frames := de.StackFrames(); len(frames) == 0 || len(frames) > 10 {
+		t.Errorf("expected the panic frames to be limited by the default factory, got %d", len(frames))
+	}
+}
+
+func panicDeeply(n int) {
+	if n == 0 {
+		panic("deep")
+	}
+
+	panicDeeply(n - 1)
+}
+
+func TestFactoryGeneratesTheIDLazily(t *testing.T) {
+	calls := 0
+	f := NewFactory(WithIDGenerator(func(context.Context) string {
+		calls++
+		return "generated"
+	}))
+
+	de := f.New(nil).AddMetadata("key", "value").AddHeader("x-key", "value")
+	if calls != 0 {
+		t.Fatalf("expected the ID not to be generated before it's used, got %d calls", calls)
+	}
+
+	if de.ID() != "generated" || de.ID() != "generated" || calls != 1 {
+		t.Errorf("expected the ID to be generated once, got %q after %d calls", de.ID(), calls)
+	}
+
+	if got := New(f.New(nil).GRPCStatus().Err()).ID(); got != "generated" {
+		t.Errorf("expected GRPCStatus to generate the ID, got %q", got)
+	}
+}
@@ -0,0 +1,119 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+)
+
+// Format implements fmt.Formatter, every value is redacted with the configured Redactor.
+//
+//	%s, %v  the message
+//	%q      the quoted message
+//	%+v     the message followed by the code, internal code, reasons, metadata and stack trace
+func (e *err) Format(s fmt.State, verb rune) {
+	message := e.factory.redactor().RedactString(e.message)
+
+	switch verb {
+	case 'v':
+		io.WriteString(s, message)
+		if s.Flag('+') {
+			e.formatDetails(s)
+		}
+	case 's':
+		io.WriteString(s, message)
+	case 'q':
+		io.WriteString(s, strconv.Quote(message))
+	default:
+		fmt.Fprintf(s, "%%!%c(errors.DetailedError=%s)", verb, message)
+	}
+}
+
+func (e *err) formatDetails(w io.Writer) {
+	fmt.Fprintf(w, "\nid: %s", e.ID())
+
+	if e.trace != nil {
+		fmt.Fprintf(w, "\ntrace: %s span: %s", e.trace.TraceID, e.trace.SpanID)
+	}
+
+	fmt.Fprintf(w, "\ncode: %d", e.code.HttpCode())
+
+	if e.internalCode != nil {
+		fmt.Fprintf(w, "\ninternal code: %s", *e.internalCode)
+	}
+
+	redactor := e.factory.redactor()
+	for _, r := range redactReasons(redactor, e.globalReasons) {
+		fmt.Fprintf(w, "\nreason: %v", r.ToHashMap())
+	}
+
+	for _, fr := range e.GetOrderedReasons() {
+		for _, r := range redactReasons(redactor, fr.Reasons) {
+			fmt.Fprintf(w, "\nreason %s: %v", fr.Key, r.ToHashMap())
+		}
+	}
+
+	md := redactMap(redactor, e.metadata)
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "\nmetadata %s: %v", k, md[k])
+	}
+
+	for _, d := range e.StackTrace() {
+		fmt.Fprintf(w, "\n%s.%s\n\t%s:%d", d.Package, d.Name, d.File, d.Line)
+		if d.Repeated > 0 {
+			fmt.Fprintf(w, "\n\t... repeated %d times", d.Repeated)
+		}
+	}
+}
+
+// LogValue implements slog.LogValuer, so loggers receive the redacted error
+func (e *err) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", e.ID()),
+		slog.String("message", e.factory.redactor().RedactString(e.message)),
+		slog.Int("code", e.code.HttpCode()),
+	}
+
+	if e.safeMessage != nil {
+		attrs = append(attrs, slog.String("safe_message", *e.safeMessage))
+	}
+
+	if e.internalCode != nil {
+		attrs = append(attrs, slog.String("internal_code", *e.internalCode))
+	}
+
+	if e.trace != nil {
+		attrs = append(attrs, slog.String("trace_id", e.trace.TraceID), slog.String("span_id", e.trace.SpanID))
+	}
+
+	if e.reportable {
+		attrs = append(attrs, slog.Bool("reportable", true))
+	}
+
+	if len(e.metadata) > 0 {
+		md := redactMap(e.factory.redactor(), e.metadata)
+		keys := make([]string, 0, len(md))
+		for k := range md {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		mdAttrs := make([]any, len(keys))
+		for i, k := range keys {
+			mdAttrs[i] = slog.Any(k, md[k])
+		}
+
+		attrs = append(attrs, slog.Group("metadata", mdAttrs...))
+	}
+
+	return slog.GroupValue(attrs...)
+}
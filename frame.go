@@ -3,6 +3,7 @@ package errors
 import (
 	"runtime"
 	"strings"
+	"sync"
 )
 
 type info struct {
@@ -11,18 +12,36 @@ type info struct {
 	File           string
 	Name           string
 	Line           int
+	// Repeated is the number of the collapsed recursive calls of the frame
+	Repeated int
 }
 
 type frame uintptr
 
-// Details method is copied from
-// https://github.com/go-errors/errors/blob/master/stackframe.go
-// https://github.com/pkg/errors/blob/master/stack.go
+// symbols caches the details by the program counter, the same frames are symbolized repeatedly
+var symbols sync.Map
+
+// Details symbolizes the frame, the frames are only symbolized when they're read
 func (f frame) Details() info {
 	if f == 0 {
 		return info{}
 	}
 
+	if d, ok := symbols.Load(f); ok {
+		return d.(info)
+	}
+
+	d := f.symbolize()
+	symbols.Store(f, d)
+
+	return d
+}
+
+// symbolize method is copied from
+// https://github.com/go-errors/errors/blob/master/stackframe.go
+// https://github.com/pkg/errors/blob/master/stack.go
+func (f frame) symbolize() info {
+
 	pc := uintptr(f) - 1
 
 	fn := runtime.FuncForPC(pc)
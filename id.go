@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ErrorIDTrailerKey is the trailer the error ID is sent with by `Send`
+const ErrorIDTrailerKey = "x-error-id"
+
+type idGeneratorFunc func(ctx context.Context) string
+
+// NewUUIDv7 generates a time-ordered UUID (RFC 9562)
+func NewUUIDv7() string {
+	var b [16]byte
+	var ts [8]byte
+
+	binary.BigEndian.PutUint64(ts[:], uint64(time.Now().UnixMilli()))
+	copy(b[:6], ts[2:])
+	_, _ = rand.Read(b[6:])
+
+	b[6] = (b[6] & 0x0f) | 0x70
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+
+	return string(buf[:])
+}
+
+var idGenerator idGeneratorFunc = func(_ context.Context) string {
+	return NewUUIDv7()
+}
+var idGeneratorSetOnce sync.Once
+
+func SetIDGenerator(generator idGeneratorFunc) {
+	idGeneratorSetOnce.Do(func() {
+		idGenerator = generator
+	})
+}
+
+var requestIDMetadataKey = ""
+var requestIDMetadataKeySetOnce sync.Once
+
+// SetRequestIDMetadataKey seeds the error IDs from the incoming gRPC metadata key. e.g. "x-request-id"
+// The errors created for the same request share the ID, so the clients can report it.
+func SetRequestIDMetadataKey(key string) {
+	requestIDMetadataKeySetOnce.Do(func() {
+		requestIDMetadataKey = key
+	})
+}
+
+func (f *Factory) requestIDFromContext(ctx context.Context) string {
+	key := f.requestIDMetadataKey()
+	if key == "" || ctx == nil {
+		return ""
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// seedID returns the ID of the request, or true if the ID must be generated.
+// The generator is only called by `ID`, `Send` and `GRPCStatus`, most errors never need their ID.
+func (f *Factory) seedID(ctx context.Context) (string, bool) {
+	if id := f.requestIDFromContext(ctx); id != "" {
+		return id, false
+	}
+
+	return "", true
+}
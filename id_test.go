@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewUUIDv7(t *testing.T) {
+	before := time.Now().UnixMilli()
+	id := NewUUIDv7()
+	after := time.Now().UnixMilli()
+
+	if len(id) != 36 || id[8] != '-' || id[13] != '-' || id[18] != '-' || id[23] != '-' {
+		t.Fatalf("expected the 8-4-4-4-12 layout, got %q", id)
+	}
+
+	if id[14] != '7' {
+		t.Errorf("expected the version 7, got %q", id[14])
+	}
+
+	// the variant bits are 10, so the first hex digit of the fourth group is 8, 9, a or b
+	if v := id[19]; v != '8' && v != '9' && v != 'a' && v != 'b' {
+		t.Errorf("expected the RFC 9562 variant, got %q", v)
+	}
+
+	ms, err := strconv.ParseInt(id[0:8]+id[9:13], 16, 64)
+	if err != nil || ms < before || ms > after {
+		t.Errorf("expected the timestamp of the generation, got %d not in [%d, %d]", ms, before, after)
+	}
+
+	if NewUUIDv7() == id {
+		t.Errorf("expected the IDs to be unique")
+	}
+}
+
+func TestSetRequestIDMetadataKey(t *testing.T) {
+	defer func(key string) {
+		requestIDMetadataKey = key
+		requestIDMetadataKeySetOnce = sync.Once{}
+	}(requestIDMetadataKey)
+
+	SetRequestIDMetadataKey("x-request-id")
+	SetRequestIDMetadataKey("x-ignored")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1", "x-ignored", "req-2"))
+
+	if id := New(nil, Context(ctx)).ID(); id != "req-1" {
+		t.Errorf("expected the ID to be seeded from the request, got %q", id)
+	}
+
+	if id := New(nil).Context(ctx).ID(); id != "req-1" {
+		t.Errorf("expected Context to seed the generated ID, got %q", id)
+	}
+
+	if id := New(nil, ErrorID("explicit")).Context(ctx).ID(); id != "explicit" {
+		t.Errorf("expected the explicit ID to be kept, got %q", id)
+	}
+
+	if id := New(nil, Context(context.Background())).ID(); len(id) != 36 {
+		t.Errorf("expected the ID to be generated without a request ID, got %q", id)
+	}
+}
+
+func TestRehydratedID(t *testing.T) {
+	trailers := func() metadata.MD {
+		return metadata.Pairs(ErrorIDTrailerKey, "from-trailer")
+	}
+
+	withDetails := New(nil, ErrorID("from-details")).GRPCStatus().Err()
+	withoutDetails := status.Error(grpccodes.NotFound, "not found")
+
+	tests := []struct {
+		name string
+		err  error
+		opts []ErrorOption
+		want string
+	}{
+		{"trailer without details", withoutDetails, []ErrorOption{Trailers(trailers())}, "from-trailer"},
+		{"details take precedence over the trailer", withDetails, []ErrorOption{Trailers(trailers())}, "from-details"},
+		{"details without trailer", withDetails, nil, "from-details"},
+		{"explicit ID", withDetails, []ErrorOption{Trailers(trailers()), ErrorID("explicit")}, "explicit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			de := New(tt.err, tt.opts...)
+			if de.ID() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, de.ID())
+			}
+
+			// the trailer is added again by `Send`
+			if values := de.GetTrailers().Get(ErrorIDTrailerKey); len(values) > 0 && tt.want != "explicit" {
+				t.Errorf("expected the received trailer to be removed, got %v", values)
+			}
+		})
+	}
+
+	// the received IDs aren't replaced by the request ID of the receiver
+	received := NewFactory(WithRequestIDMetadataKey("x-request-id")).New(withDetails)
+	received.Context(metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1")))
+	if received.ID() != "from-details" {
+		t.Errorf("expected the received ID to be kept, got %q", received.ID())
+	}
+}
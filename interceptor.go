@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// StripInternalDetailsUnaryServerInterceptor removes the internal details from the errors returned to the clients,
+// it should be installed on the services at the trust boundaries. e.g. the edge gateway
+func StripInternalDetailsUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, StripInternalDetails(err)
+		}
+
+		return resp, nil
+	}
+}
+
+func StripInternalDetailsStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return StripInternalDetails(err)
+		}
+
+		return nil
+	}
+}
+
+// StripInternalDetailsUnaryClientInterceptor removes the internal details from the errors received from the services
+func StripInternalDetailsUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return StripInternalDetails(err)
+		}
+
+		return nil
+	}
+}
+
+// RecoveryUnaryServerInterceptor converts the panics of the handlers into reportable errors, which are sent with `Send`
+func RecoveryUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, r).Send()
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func RecoveryStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), r).Send()
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
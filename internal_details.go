@@ -0,0 +1,266 @@
+package errors
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+type InternalDetailsMode int
+
+const (
+	// SignInternalDetails sends the internal details in clear text with an HMAC-SHA256 signature
+	SignInternalDetails InternalDetailsMode = iota + 1
+	// EncryptInternalDetails sends the internal details encrypted with AES-GCM, the key must be 16, 24 or 32 bytes
+	EncryptInternalDetails
+)
+
+// InternalDetailsKey protects the internal details, it's created with NewInternalDetailsKey
+type InternalDetailsKey struct {
+	mode InternalDetailsMode
+	key  []byte
+	id   string
+}
+
+// NewInternalDetailsKey validates the key for the mode, it's used with WithInternalDetailsKey
+func NewInternalDetailsKey(mode InternalDetailsMode, key []byte) (*InternalDetailsKey, error) {
+	if len(key) == 0 {
+		return nil, errors.New("internal details key is empty")
+	}
+
+	switch mode {
+	case SignInternalDetails:
+	case EncryptInternalDetails:
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown internal details mode: %d", mode)
+	}
+
+	sum := sha256.Sum256(key)
+
+	return &InternalDetailsKey{
+		mode: mode,
+		key:  append([]byte(nil), key...),
+		id:   hex.EncodeToString(sum[:8]),
+	}, nil
+}
+
+var internalKey *InternalDetailsKey
+var internalKeySetOnce sync.Once
+
+// SetInternalDetailsKey enables the internal details. The internal metadata and the stack trace are sent
+// in an extra detail, which is only decoded by `New` when the same key is configured.
+// It returns an error when another key is already set.
+func SetInternalDetailsKey(mode InternalDetailsMode, key []byte) error {
+	k, err := NewInternalDetailsKey(mode, key)
+	if err != nil {
+		return err
+	}
+
+	internalKeySetOnce.Do(func() {
+		internalKey = k
+	})
+
+	if internalKey.mode != k.mode || !hmac.Equal(internalKey.key, k.key) {
+		return errors.New("internal details key is already set")
+	}
+
+	return nil
+}
+
+func (k *InternalDetailsKey) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, k.key)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+func (k *InternalDetailsKey) seal(payload *InternalPayload) (*anypb.Any, error) {
+	bytes, err := proto.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &InternalDetails{
+		KeyId: k.id,
+	}
+
+	if k.mode == SignInternalDetails {
+		details.Protection = InternalDetails_PROTECTION_SIGNED
+		details.Payload = bytes
+		details.Signature = k.sign(bytes)
+
+		return anypb.New(details)
+	}
+
+	block, err := aes.NewCipher(k.key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	details.Protection = InternalDetails_PROTECTION_ENCRYPTED
+	details.Nonce = nonce
+	details.Payload = gcm.Seal(nil, nonce, bytes, []byte(k.id))
+
+	return anypb.New(details)
+}
+
+// open returns nil when the details were protected with another key or have been tampered with
+func (k *InternalDetailsKey) open(details *InternalDetails) *InternalPayload {
+	if details.KeyId != k.id {
+		return nil
+	}
+
+	var bytes []byte
+
+	switch details.Protection {
+	case InternalDetails_PROTECTION_SIGNED:
+		if !hmac.Equal(details.Signature, k.sign(details.Payload)) {
+			return nil
+		}
+
+		bytes = details.Payload
+	case InternalDetails_PROTECTION_ENCRYPTED:
+		block, err := aes.NewCipher(k.key)
+		if err != nil {
+			return nil
+		}
+
+		gcm, err := cipher.NewGCM(block)
+		if err != nil || len(details.Nonce) != gcm.NonceSize() {
+			return nil
+		}
+
+		if bytes, err = gcm.Open(nil, details.Nonce, details.Payload, []byte(k.id)); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	payload := &InternalPayload{}
+	if err := proto.Unmarshal(bytes, payload); err != nil {
+		return nil
+	}
+
+	return payload
+}
+
+func (e *err) internalDetails() (*anypb.Any, error) {
+	payload := &InternalPayload{}
+
+	md, err := mapToV2(redactMap(e.factory.redactor(), e.GetMetadataFor(VisibilityInternal)))
+	if err != nil {
+		return nil, err
+	}
+
+	payload.Metadata = md
+
+	// the remote frames are forwarded, so the stack of the origin is kept through the hops
+	frames := e.remoteFrames
+	if len(frames) == 0 {
+		frames = e.StackTrace()
+	}
+
+	payload.Frames = make([]*InternalPayload_Frame, 0, len(frames))
+	for _, f := range frames {
+		payload.Frames = append(payload.Frames, &InternalPayload_Frame{
+			Package: f.Package,
+			Name:    f.Name,
+			File:    f.File,
+			Line:    int64(f.Line),
+		})
+	}
+
+	return e.factory.internalDetailsKey().seal(payload)
+}
+
+// decodeInternalDetails ignores the details protected with another key
+func (e *err) decodeInternalDetails(detail any) {
+	key := e.factory.internalDetailsKey()
+	anyErr, ok := detail.(*anypb.Any)
+	if !ok || key == nil {
+		return
+	}
+
+	details := &InternalDetails{}
+	if err := anyErr.UnmarshalTo(details); err != nil {
+		return
+	}
+
+	payload := key.open(details)
+	if payload == nil {
+		return
+	}
+
+	for k, v := range mapFromV2(payload.Metadata) {
+		if _, ok := e.metadata[k]; !ok {
+			e.AddMetadataWithVisibility(k, v, VisibilityInternal)
+		}
+	}
+
+	e.remoteFrames = make([]info, len(payload.Frames))
+	for i, f := range payload.Frames {
+		e.remoteFrames[i] = info{
+			Package: f.Package,
+			Name:    f.Name,
+			File:    f.File,
+			Line:    int(f.Line),
+		}
+	}
+}
+
+// IsInternalDetails reports whether a status detail is the internal details message
+func IsInternalDetails(detail any) bool {
+	anyErr, ok := detail.(*anypb.Any)
+
+	return ok && anyErr.MessageIs(&InternalDetails{})
+}
+
+// StripInternalDetails removes the internal details from a gRPC status error, the other errors are returned as is
+func StripInternalDetails(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st == nil {
+		return err
+	}
+
+	pb := st.Proto()
+	details := pb.Details[:0]
+	for _, detail := range pb.Details {
+		if inner, e := detail.UnmarshalNew(); e == nil && IsInternalDetails(inner) {
+			continue
+		}
+
+		details = append(details, detail)
+	}
+
+	if len(details) == len(st.Proto().Details) {
+		return err
+	}
+
+	pb.Details = details
+
+	return status.FromProto(pb).Err()
+}
@@ -0,0 +1,208 @@
+package errors
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func internalDetailsFactory(t *testing.T, mode InternalDetailsMode, key string) *Factory {
+	t.Helper()
+
+	k, err := NewInternalDetailsKey(mode, []byte(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewFactory(WithInternalDetailsKey(k))
+}
+
+// withInternalDetails returns the status error of an error carrying internal metadata
+func withInternalDetails(f *Factory) error {
+	return f.New(nil, Message("failed")).
+		AddMetadataWithVisibility("sql", "select 1", VisibilityInternal).
+		GRPCStatus().Err()
+}
+
+// tamper rewrites the internal details of the status error
+func tamper(t *testing.T, e error, modify func(*InternalDetails)) error {
+	t.Helper()
+
+	pb := status.Convert(e).Proto()
+	for i, detail := range pb.Details {
+		inner, err := detail.UnmarshalNew()
+		if err != nil || !IsInternalDetails(inner) {
+			continue
+		}
+
+		details := &InternalDetails{}
+		if err := inner.(*anypb.Any).UnmarshalTo(details); err != nil {
+			t.Fatal(err)
+		}
+
+		modify(details)
+
+		wrapped, err := anypb.New(details)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if pb.Details[i], err = anypb.New(wrapped); err != nil {
+			t.Fatal(err)
+		}
+
+		return status.FromProto(pb).Err()
+	}
+
+	t.Fatalf("expected the internal details in the status")
+
+	return nil
+}
+
+func assertInternalDetails(t *testing.T, de DetailedError, decoded bool) {
+	t.Helper()
+
+	if got := de.GetMetadata()["sql"] == "select 1" && len(de.RemoteStackFrames()) > 0; got != decoded {
+		t.Errorf("expected the internal details to be decoded: %v, got metadata %v and %d remote frames",
+			decoded, de.GetMetadata(), len(de.RemoteStackFrames()))
+	}
+}
+
+func TestInternalDetailsRoundTrip(t *testing.T) {
+	for name, mode := range map[string]InternalDetailsMode{"signed": SignInternalDetails, "encrypted": EncryptInternalDetails} {
+		t.Run(name, func(t *testing.T) {
+			f := internalDetailsFactory(t, mode, "0123456789abcdef0123456789abcdef")
+			e := withInternalDetails(f)
+
+			assertInternalDetails(t, f.New(e), true)
+			assertInternalDetails(t, NewFactory().New(e), false)
+			assertInternalDetails(t, internalDetailsFactory(t, mode, "fedcba9876543210fedcba9876543210").New(e), false)
+		})
+	}
+}
+
+func TestInternalDetailsRejected(t *testing.T) {
+	cases := []struct {
+		name   string
+		mode   InternalDetailsMode
+		modify func(*InternalDetails)
+	}{
+		{"signed payload", SignInternalDetails, func(d *InternalDetails) { d.Payload[0] ^= 1 }},
+		{"signature", SignInternalDetails, func(d *InternalDetails) { d.Signature[0] ^= 1 }},
+		{"encrypted payload", EncryptInternalDetails, func(d *InternalDetails) { d.Payload[0] ^= 1 }},
+		{"key id", SignInternalDetails, func(d *InternalDetails) { d.KeyId = "0000000000000000" }},
+		{"nonce length", EncryptInternalDetails, func(d *InternalDetails) { d.Nonce = d.Nonce[:4] }},
+		{"protection", EncryptInternalDetails, func(d *InternalDetails) { d.Protection = InternalDetails_PROTECTION_SIGNED }},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := internalDetailsFactory(t, tc.mode, "0123456789abcdef")
+			de := f.New(tamper(t, withInternalDetails(f), tc.modify))
+
+			assertInternalDetails(t, de, false)
+
+			if de.Error() != "failed" {
+				t.Errorf("expected the public details to be kept, got %q", de.Error())
+			}
+		})
+	}
+}
+
+func TestNewInternalDetailsKey(t *testing.T) {
+	if _, err := NewInternalDetailsKey(SignInternalDetails, nil); err == nil {
+		t.Errorf("expected an empty key to be rejected")
+	}
+
+	if _, err := NewInternalDetailsKey(EncryptInternalDetails, []byte("short")); err == nil {
+		t.Errorf("expected an invalid AES key to be rejected")
+	}
+
+	if _, err := NewInternalDetailsKey(InternalDetailsMode(7), []byte("key")); err == nil {
+		t.Errorf("expected an unknown mode to be rejected")
+	}
+}
+
+func TestSetInternalDetailsKey(t *testing.T) {
+	defer func() {
+		internalKey = nil
+		internalKeySetOnce = sync.Once{}
+	}()
+
+	if err := SetInternalDetailsKey(SignInternalDetails, []byte("first")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetInternalDetailsKey(SignInternalDetails, []byte("first")); err != nil {
+		t.Errorf("expected the same key to be accepted again, got %v", err)
+	}
+
+	if err := SetInternalDetailsKey(SignInternalDetails, []byte("second")); err == nil {
+		t.Errorf("expected another key to be rejected")
+	}
+
+	if err := SetInternalDetailsKey(EncryptInternalDetails, []byte("0123456789abcdef")); err == nil {
+		t.Errorf("expected another mode to be rejected")
+	}
+}
+
+func TestStripInternalDetails(t *testing.T) {
+	f := internalDetailsFactory(t, SignInternalDetails, "0123456789abcdef")
+	e := withInternalDetails(f)
+
+	assertStripped := func(t *testing.T, stripped error) {
+		t.Helper()
+
+		st := status.Convert(stripped)
+		if st.Message() != "failed" || len(st.Details()) != len(status.Convert(e).Details())-1 {
+			t.Errorf("expected only the internal details to be removed, got %q with %d details", st.Message(), len(st.Details()))
+		}
+
+		for _, detail := range st.Details() {
+			if IsInternalDetails(detail) {
+				t.Errorf("expected the internal details to be removed")
+			}
+		}
+
+		assertInternalDetails(t, f.New(stripped), false)
+	}
+
+	t.Run("function", func(t *testing.T) {
+		assertStripped(t, StripInternalDetails(e))
+
+		if plain := context.Canceled; StripInternalDetails(plain) != plain {
+			t.Errorf("expected the other errors to be returned as is")
+		}
+	})
+
+	t.Run("unary server", func(t *testing.T) {
+		_, stripped := StripInternalDetailsUnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{},
+			func(context.Context, any) (any, error) {
+				return nil, e
+			})
+
+		assertStripped(t, stripped)
+	})
+
+	t.Run("stream server", func(t *testing.T) {
+		stripped := StripInternalDetailsStreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{},
+			func(any, grpc.ServerStream) error {
+				return e
+			})
+
+		assertStripped(t, stripped)
+	})
+
+	t.Run("unary client", func(t *testing.T) {
+		stripped := StripInternalDetailsUnaryClientInterceptor()(context.Background(), "/test.Service/Method", nil, nil, nil,
+			func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				return e
+			})
+
+		assertStripped(t, stripped)
+	})
+}
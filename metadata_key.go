@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"encoding/json"
+)
+
+// MetadataCodec converts a typed metadata value to and from the value stored in the metadata.
+// The encoded value must be something the marshalers can encode, see normalizeValue.
+type MetadataCodec[T any] interface {
+	Encode(value T) (interface{}, error)
+	Decode(value interface{}) (T, error)
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Encode(value T) (interface{}, error) {
+	return normalizeJSON(value)
+}
+
+func (jsonCodec[T]) Decode(value interface{}) (T, error) {
+	var decoded T
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return decoded, err
+	}
+
+	err = json.Unmarshal(bytes, &decoded)
+
+	return decoded, err
+}
+
+// JSONCodec stores the value as its JSON representation. It is the default codec of MetadataKey,
+// numbers decoded as float64 and times formatted as strings are converted back to T.
+func JSONCodec[T any]() MetadataCodec[T] {
+	return jsonCodec[T]{}
+}
+
+type funcCodec[T any] struct {
+	encode func(T) (interface{}, error)
+	decode func(interface{}) (T, error)
+}
+
+func (c funcCodec[T]) Encode(value T) (interface{}, error) {
+	return c.encode(value)
+}
+
+func (c funcCodec[T]) Decode(value interface{}) (T, error) {
+	return c.decode(value)
+}
+
+// NewMetadataCodec creates a codec from the encode and decode functions
+func NewMetadataCodec[T any](encode func(T) (interface{}, error), decode func(interface{}) (T, error)) MetadataCodec[T] {
+	return funcCodec[T]{
+		encode: encode,
+		decode: decode,
+	}
+}
+
+// MetadataKey is a typed metadata key, so services can share the metadata contracts at compile time.
+//
+//	var UserID = errors.NewMetadataKey[int64]("user_id")
+//
+//	UserID.Set(de, 42)
+//	id, ok := UserID.Get(errors.New(grpcErr))
+type MetadataKey[T any] struct {
+	name  string
+	codec MetadataCodec[T]
+}
+
+// NewMetadataKey creates a new typed key, JSONCodec is used when the codec is not provided
+func NewMetadataKey[T any](name string, codec ...MetadataCodec[T]) MetadataKey[T] {
+	key := MetadataKey[T]{
+		name:  name,
+		codec: JSONCodec[T](),
+	}
+
+	if len(codec) > 0 && codec[0] != nil {
+		key.codec = codec[0]
+	}
+
+	return key
+}
+
+func (k MetadataKey[T]) Name() string {
+	return k.name
+}
+
+// Set encodes the value and adds it to the metadata.
+// If the value can't be encoded, it's reported to the metadata warner and the metadata is left untouched.
+func (k MetadataKey[T]) Set(de DetailedError, value T) DetailedError {
+	encoded, err := k.codec.Encode(value)
+	if err != nil {
+		warnerOf(de)(k.name, value, err)
+		return de
+	}
+
+	return de.AddMetadata(k.name, encoded)
+}
+
+// Get decodes the value from the metadata. It returns false if the key is missing or the value can't be decoded.
+func (k MetadataKey[T]) Get(de DetailedError) (T, bool) {
+	var zero T
+
+	if de == nil {
+		return zero, false
+	}
+
+	value, ok := de.GetMetadata()[k.name]
+	if !ok {
+		return zero, false
+	}
+
+	decoded, err := k.codec.Decode(value)
+	if err != nil {
+		return zero, false
+	}
+
+	return decoded, true
+}
+
+func (k MetadataKey[T]) Has(de DetailedError) bool {
+	return de != nil && de.HasMetadata(k.name)
+}
+
+// warnerOf returns the metadata warner of the factory the error was created with
+func warnerOf(de DetailedError) metadataWarnerFunc {
+	if e, ok := de.(*err); ok {
+		return e.factory.metadataWarner()
+	}
+
+	return defaultFactory.Load().metadataWarner()
+}
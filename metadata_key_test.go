@@ -0,0 +1,96 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+)
+
+type shipment struct {
+	ID      int64     `json:"id"`
+	Carrier string    `json:"carrier"`
+	Tags    []string  `json:"tags"`
+	At      time.Time `json:"at"`
+}
+
+var errCodec = stderrors.New("codec failed")
+
+var (
+	userIDKey   = NewMetadataKey[int64]("user_id")
+	deadlineKey = NewMetadataKey[time.Time]("deadline")
+	shipmentKey = NewMetadataKey[shipment]("shipment")
+)
+
+func TestMetadataKeyRoundTrip(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 30, 0, 123, time.UTC)
+	want := shipment{ID: 7, Carrier: "dhl", Tags: []string{"fragile"}, At: at}
+
+	for _, tt := range []struct {
+		name    string
+		version DetailsVersion
+		userID  int64
+	}{
+		// the v1 details carry the numbers as float64, the safe integers survive them
+		{"v1", DetailsV1, 1<<53 - 1},
+		{"v2", DetailsV2, 1<<62 + 1},
+		{"v1 and v2", DetailsV1AndV2, 1<<62 + 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewFactory(WithDetailsVersion(tt.version)).New(nil).IncludeMetadata()
+			userIDKey.Set(src, tt.userID)
+			deadlineKey.Set(src, at)
+			shipmentKey.Set(src, want)
+
+			de := New(src.GRPCStatus().Err())
+
+			if got, ok := userIDKey.Get(de); !ok || got != tt.userID {
+				t.Errorf("expected the user ID %d, got %d, %v", tt.userID, got, ok)
+			}
+
+			if got, ok := deadlineKey.Get(de); !ok || !got.Equal(at) {
+				t.Errorf("expected the deadline %v, got %v, %v", at, got, ok)
+			}
+
+			got, ok := shipmentKey.Get(de)
+			if !ok || got.ID != want.ID || got.Carrier != want.Carrier || len(got.Tags) != 1 || !got.At.Equal(at) {
+				t.Errorf("expected the shipment %+v, got %+v, %v", want, got, ok)
+			}
+
+			if !shipmentKey.Has(de) || NewMetadataKey[string]("missing").Has(de) {
+				t.Errorf("expected Has to report the received keys only")
+			}
+		})
+	}
+}
+
+func TestMetadataKeyErrors(t *testing.T) {
+	var warned []string
+	f := NewFactory(WithMetadataWarner(func(key string, _ interface{}, _ error) {
+		warned = append(warned, key)
+	}))
+
+	failing := NewMetadataKey[int]("failing", NewMetadataCodec(
+		func(int) (interface{}, error) { return nil, errCodec },
+		func(interface{}) (int, error) { return 0, errCodec },
+	))
+
+	de := f.New(nil)
+	failing.Set(de, 1)
+
+	if de.HasMetadata("failing") || len(warned) != 1 || warned[0] != "failing" {
+		t.Errorf("expected the value to be reported to the warner and left out, got %v", warned)
+	}
+
+	de.AddMetadata("user_id", "not a number").AddMetadata("failing", 1)
+	if _, ok := userIDKey.Get(de); ok {
+		t.Errorf("expected a value of another type not to be decoded")
+	}
+
+	if _, ok := failing.Get(de); ok {
+		t.Errorf("expected the decoding error to be reported")
+	}
+
+	if _, ok := userIDKey.Get(nil); ok {
+		t.Errorf("expected a nil error to have no metadata")
+	}
+}
@@ -0,0 +1,225 @@
+package errors
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+type MetadataPolicy int
+
+const (
+	// DropInvalidMetadata drops the metadata values that can't be marshaled and reports them to the warner
+	DropInvalidMetadata MetadataPolicy = iota
+	// FailOnInvalidMetadata fails the marshalling, `GRPCStatus` then returns an InternalServerError
+	FailOnInvalidMetadata
+)
+
+var metadataPolicy = DropInvalidMetadata
+var metadataPolicySetOnce sync.Once
+
+func SetMetadataPolicy(policy MetadataPolicy) {
+	metadataPolicySetOnce.Do(func() {
+		metadataPolicy = policy
+	})
+}
+
+type metadataWarnerFunc func(key string, value interface{}, err error)
+
+var metadataWarner metadataWarnerFunc = func(key string, value interface{}, err error) {
+	log.Printf("errors: dropping metadata %q of type %T: %v", key, value, err)
+}
+var metadataWarnerSetOnce sync.Once
+
+// SetMetadataWarner sets the function called for every metadata value dropped by DropInvalidMetadata
+func SetMetadataWarner(warner metadataWarnerFunc) {
+	metadataWarnerSetOnce.Do(func() {
+		metadataWarner = warner
+	})
+}
+
+var timeFormat = time.RFC3339Nano
+var timeFormatSetOnce sync.Once
+
+// SetTimeFormat sets the layout used to marshal time.Time values
+func SetTimeFormat(layout string) {
+	timeFormatSetOnce.Do(func() {
+		timeFormat = layout
+	})
+}
+
+// normalizeMetadata converts the metadata values into the types the marshalers can encode
+func normalizeMetadata(md map[string]interface{}) (map[string]interface{}, error) {
+	return normalizeMetadataWith(md, defaultFactory.Load())
+}
+
+// normalizeMetadataWith uses the policy, the warner and the time format of the factory
+func normalizeMetadataWith(md map[string]interface{}, f *Factory) (map[string]interface{}, error) {
+	policy, layout := f.metadataPolicy(), f.timeFormat()
+
+	normalized := make(map[string]interface{}, len(md))
+	for k, v := range md {
+		value, err := normalizeValueWith(v, layout)
+		if err == nil {
+			normalized[k] = value
+			continue
+		}
+
+		if policy == FailOnInvalidMetadata {
+			return nil, fmt.Errorf("metadata %q: %w", k, err)
+		}
+
+		f.metadataWarner()(k, v, err)
+	}
+
+	return normalized, nil
+}
+
+// normalizeValue converts v into nil, bool, string, int64, uint64, float64, []byte, []interface{} or map[string]interface{}
+func normalizeValue(v interface{}) (interface{}, error) {
+	return normalizeValueWith(v, defaultFactory.Load().timeFormat())
+}
+
+// normalizeValueWith formats the time.Time values with the layout
+func normalizeValueWith(v interface{}, layout string) (interface{}, error) {
+	// the typed nil pointers are checked before the interface cases, their value methods would panic
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, nil
+	}
+
+	switch v := v.(type) {
+	case string:
+		if !utf8.ValidString(v) {
+			return nil, fmt.Errorf("invalid UTF-8 in string: %q", v)
+		}
+
+		return v, nil
+	case nil, bool, []byte, json.Number,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return v, nil
+	case time.Time:
+		return v.Format(layout), nil
+	case time.Duration:
+		return v.String(), nil
+	case error:
+		return v.Error(), nil
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+
+		return string(text), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	case json.Marshaler:
+		return normalizeJSON(v)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+
+		return normalizeValueWith(rv.Elem().Interface(), layout)
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.String:
+		return normalizeValueWith(rv.String(), layout)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, nil
+		}
+
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			item, err := normalizeValueWith(rv.Index(i).Interface(), layout)
+			if err != nil {
+				return nil, err
+			}
+
+			list[i] = item
+		}
+
+		return list, nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+
+		if rv.Type().Key().Kind() != reflect.String {
+			return normalizeJSON(v)
+		}
+
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			item, err := normalizeValueWith(iter.Value().Interface(), layout)
+			if err != nil {
+				return nil, err
+			}
+
+			m[iter.Key().String()] = item
+		}
+
+		return m, nil
+	}
+
+	return normalizeJSON(v)
+}
+
+// normalizeJSON round-trips v through encoding/json, integers are kept as int64
+func normalizeJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	return fromJSONNumbers(decoded), nil
+}
+
+func fromJSONNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i, item := range v {
+			v[i] = fromJSONNumbers(item)
+		}
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = fromJSONNumbers(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type nilPointerErr struct{}
+
+func (*nilPointerErr) Error() string {
+	return "nil pointer error"
+}
+
+func TestNormalizeTypedNilPointers(t *testing.T) {
+	for name, value := range map[string]interface{}{
+		"time":  (*time.Time)(nil),
+		"error": (*nilPointerErr)(nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			normalized, err := normalizeValue(value)
+			if err != nil || normalized != nil {
+				t.Errorf("expected nil, got %v, %v", normalized, err)
+			}
+
+			de := New(nil).IncludeMetadata().AddMetadata("deleted_at", value)
+			if st := de.GRPCStatus(); st.Code() != de.GetCode().GrpcCode() {
+				t.Errorf("expected the status of the error, got %s %q", st.Code(), st.Message())
+			}
+
+			_ = fmt.Sprintf("%+v", de)
+			_ = de.(slog.LogValuer).LogValue().Resolve()
+		})
+	}
+}
@@ -2,13 +2,16 @@ package errors
 
 import (
 	"context"
+	"time"
 
 	"github.com/poorly-written/grpc-http-response/codes"
 	"google.golang.org/grpc/metadata"
 )
 
 type errorOptions struct {
+	id           string
 	message      string
+	safeMessage  *string
 	headers      metadata.MD
 	trailers     metadata.MD
 	callerOffset int
@@ -16,7 +19,10 @@ type errorOptions struct {
 	internalCode *string
 	code         codes.Code
 	reportable   bool
+	retryable    *bool
+	retryAfter   *time.Duration
 	skipIfNil    bool
+	stackCapture *StackCaptureMode
 }
 
 type ErrorOption interface {
@@ -42,6 +48,24 @@ func newFuncErrorOption(f errorOptionModifier) *funcErrorOption {
 func Message(msg string) ErrorOption {
 	return newFuncErrorOption(func(_ error, o *errorOptions) {
 		o.message = msg
+		o.safeMessage = nil
+	})
+}
+
+// Messagef sets a formatted message, see Newf
+func Messagef(format string, args ...interface{}) ErrorOption {
+	full, safe := sprintfSafe(format, args...)
+
+	return newFuncErrorOption(func(_ error, o *errorOptions) {
+		o.message = full
+		o.safeMessage = &safe
+	})
+}
+
+// ErrorID overrides the generated error ID
+func ErrorID(id string) ErrorOption {
+	return newFuncErrorOption(func(_ error, o *errorOptions) {
+		o.id = id
 	})
 }
 
@@ -100,6 +124,37 @@ func Reportable() ErrorOption {
 	})
 }
 
+func Retryable() ErrorOption {
+	return newFuncErrorOption(func(_ error, o *errorOptions) {
+		retryable := true
+		o.retryable = &retryable
+	})
+}
+
+func NotRetryable() ErrorOption {
+	return newFuncErrorOption(func(_ error, o *errorOptions) {
+		retryable := false
+		o.retryable = &retryable
+		o.retryAfter = nil
+	})
+}
+
+// RetryAfter marks the error as retryable after the delay
+func RetryAfter(d time.Duration) ErrorOption {
+	return newFuncErrorOption(func(_ error, o *errorOptions) {
+		retryable := true
+		o.retryable = &retryable
+		o.retryAfter = &d
+	})
+}
+
+// CaptureStack overrides the capture mode of the code
+func CaptureStack(mode StackCaptureMode) ErrorOption {
+	return newFuncErrorOption(func(_ error, o *errorOptions) {
+		o.stackCapture = &mode
+	})
+}
+
 func SkipIfNil() ErrorOption {
 	return newFuncErrorOption(func(_ error, o *errorOptions) {
 		o.skipIfNil = true
@@ -0,0 +1,56 @@
+package otelerrors
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor records the returned errors on the span of the request,
+// it must be chained after the interceptor starting the span
+func (r *Recorder) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			r.record(ctx, trace.SpanFromContext(ctx), err, false)
+		}
+
+		return resp, err
+	}
+}
+
+func (r *Recorder) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			r.record(ss.Context(), trace.SpanFromContext(ss.Context()), err, false)
+		}
+
+		return err
+	}
+}
+
+// UnaryClientInterceptor records the received errors on the client span, every error code sets the span status
+func (r *Recorder) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			r.record(ctx, trace.SpanFromContext(ctx), err, true)
+		}
+
+		return err
+	}
+}
+
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	return NewRecorder(opts...).UnaryServerInterceptor()
+}
+
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	return NewRecorder(opts...).StreamServerInterceptor()
+}
+
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	return NewRecorder(opts...).UnaryClientInterceptor()
+}
@@ -0,0 +1,192 @@
+// Package otelerrors records the errors of github.com/poorly-written/go-errors on OpenTelemetry spans and metrics.
+// It's a separate module, so the core package doesn't depend on OpenTelemetry.
+package otelerrors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/poorly-written/go-errors"
+	"github.com/poorly-written/grpc-http-response/codes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+const instrumentationName = "github.com/poorly-written/go-errors/otelerrors"
+
+const (
+	CodeKey         = attribute.Key("error.code")
+	InternalCodeKey = attribute.Key("error.internal_code")
+	IDKey           = attribute.Key("error.id")
+	ReasonsCountKey = attribute.Key("error.reasons_count")
+)
+
+type config struct {
+	meterProvider metric.MeterProvider
+}
+
+type Option func(*config)
+
+// WithMeterProvider sets the meter provider of the error counter, it defaults to the global one
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Recorder records the errors on the spans and counts them by code and internal code
+type Recorder struct {
+	counter metric.Int64Counter
+}
+
+func NewRecorder(opts ...Option) *Recorder {
+	c := &config{
+		meterProvider: otel.GetMeterProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	counter, err := c.meterProvider.Meter(instrumentationName).Int64Counter(
+		"errors",
+		metric.WithDescription("The number of the recorded errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &Recorder{
+		counter: counter,
+	}
+}
+
+var defaultRecorder *Recorder
+var defaultRecorderOnce sync.Once
+
+// Record records the error with a recorder using the global meter provider
+func Record(span trace.Span, err error) {
+	defaultRecorderOnce.Do(func() {
+		defaultRecorder = NewRecorder()
+	})
+
+	defaultRecorder.Record(span, err)
+}
+
+// Record sets the span status from the code of the error, adds an "exception" event
+// and increments the error counter. The span is treated as a server span, its status is only set
+// for the server errors, the client errors are left Unset like the OpenTelemetry conventions require.
+// Nil errors and the errors with a non-error code are ignored.
+func (r *Recorder) Record(span trace.Span, err error) {
+	r.record(context.Background(), span, err, false)
+}
+
+// record sets the status of the client spans for the client errors too
+func (r *Recorder) record(ctx context.Context, span trace.Span, err error, client bool) {
+	if err == nil {
+		return
+	}
+
+	// the plain errors are unexpected, they're classified as server errors like errors.BoundaryPolicy does.
+	// The stack isn't captured, the frames of the recorder would be meaningless.
+	opts := []errors.ErrorOption{errors.CaptureStack(errors.CaptureNoStack)}
+	if _, ok := status.FromError(err); !ok {
+		opts = append(opts, errors.ErrorCode(codes.InternalServerError))
+	}
+
+	de := errors.New(err, opts...)
+	code := de.GetCode()
+	if !code.IsError() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		CodeKey.Int(code.HttpCode()),
+	}
+
+	if internalCode, ok := de.GetInternalCode(); ok {
+		attrs = append(attrs, InternalCodeKey.String(internalCode))
+	}
+
+	if r.counter != nil {
+		r.counter.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	if span == nil || !span.IsRecording() {
+		return
+	}
+
+	// the message is formatted, so it's redacted with the configured errors.Redactor
+	message := fmt.Sprintf("%s", de)
+
+	if client || code.HttpCode() >= http.StatusInternalServerError {
+		span.SetStatus(otelcodes.Error, message)
+	}
+
+	attrs = append(attrs,
+		attribute.String("exception.type", fmt.Sprintf("%T", err)),
+		attribute.String("exception.message", message),
+		IDKey.String(de.ID()),
+		ReasonsCountKey.Int(reasonsCount(de)),
+	)
+
+	if st := stacktrace(de); st != "" {
+		attrs = append(attrs, attribute.String("exception.stacktrace", st))
+	}
+
+	span.AddEvent("exception", trace.WithAttributes(attrs...))
+}
+
+func reasonsCount(de errors.DetailedError) int {
+	count := len(de.GetGlobalReasons())
+	for _, fr := range de.GetOrderedReasons() {
+		count += len(fr.Reasons)
+	}
+
+	return count
+}
+
+// stacktrace renders the frames like the panics, the remote frames are preferred when they're available.
+// It's empty for the errors received from another service without their internal details.
+func stacktrace(de errors.DetailedError) string {
+	var sb strings.Builder
+
+	if remote := de.RemoteStackFrames(); len(remote) > 0 {
+		for _, d := range remote {
+			fmt.Fprintf(&sb, "%s.%s\n\t%s:%d\n", d.Package, d.Name, d.File, d.Line)
+		}
+
+		return sb.String()
+	}
+
+	for _, d := range de.StackTrace() {
+		fmt.Fprintf(&sb, "%s.%s\n\t%s:%d\n", d.Package, d.Name, d.File, d.Line)
+	}
+
+	return sb.String()
+}
+
+// TraceContextProvider supplies the IDs of the span in the context to the errors,
+// it's used with errors.SetTraceContextProvider
+func TraceContextProvider() errors.TraceContextProvider {
+	return errors.TraceContextProviderFunc(func(ctx context.Context) (errors.TraceContext, bool) {
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return errors.TraceparentProvider.TraceContext(ctx)
+		}
+
+		return errors.TraceContext{
+			TraceID: sc.TraceID().String(),
+			SpanID:  sc.SpanID().String(),
+			Sampled: sc.IsSampled(),
+		}, true
+	})
+}
@@ -0,0 +1,247 @@
+package otelerrors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/poorly-written/go-errors"
+	"github.com/poorly-written/go-errors/otelerrors"
+	"github.com/poorly-written/grpc-http-response/codes"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+)
+
+type harness struct {
+	exporter *tracetest.InMemoryExporter
+	reader   *sdkmetric.ManualReader
+	tracer   *sdktrace.TracerProvider
+	recorder *otelerrors.Recorder
+}
+
+func newHarness(t *testing.T) *harness {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	h := &harness{
+		exporter: exporter,
+		reader:   reader,
+		tracer:   sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		recorder: otelerrors.NewRecorder(otelerrors.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))),
+	}
+
+	t.Cleanup(func() {
+		h.tracer.Shutdown(context.Background())
+	})
+
+	return h
+}
+
+func (h *harness) span(t *testing.T) tracetest.SpanStub {
+	t.Helper()
+
+	spans := h.exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected a single span, got %d", len(spans))
+	}
+
+	return spans[0]
+}
+
+// count returns the value of the error counter for the attributes
+func (h *harness) count(t *testing.T, attrs ...attribute.KeyValue) int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := h.reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	want := attribute.NewSet(attrs...)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != "errors" || !ok {
+				continue
+			}
+
+			for _, dp := range sum.DataPoints {
+				if dp.Attributes.Equals(&want) {
+					return dp.Value
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+func attributesOf(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+
+	return m
+}
+
+func TestRecord(t *testing.T) {
+	h := newHarness(t)
+
+	_, span := h.tracer.Tracer("test").Start(context.Background(), "call")
+	de := errors.New(nil, errors.Message("failed for bob@example.com"), errors.ErrorCode(codes.InternalServerError), errors.InternalCode("db_down")).
+		AddReason("name", errors.SimpleReason("required")).
+		AddGlobalReason(errors.SimpleReason("invalid"))
+
+	h.recorder.Record(span, de)
+	span.End()
+
+	stub := h.span(t)
+	if stub.Status.Code != otelcodes.Error {
+		t.Errorf("expected the error status, got %v", stub.Status)
+	}
+
+	if stub.Status.Description != "failed for "+errors.DefaultRedactionMask {
+		t.Errorf("expected the redacted message, got %q", stub.Status.Description)
+	}
+
+	if len(stub.Events) != 1 || stub.Events[0].Name != "exception" {
+		t.Fatalf("expected an exception event, got %v", stub.Events)
+	}
+
+	attrs := attributesOf(stub.Events[0].Attributes)
+	if attrs[otelerrors.IDKey].AsString() != de.ID() {
+		t.Errorf("expected the error ID, got %v", attrs[otelerrors.IDKey])
+	}
+
+	if attrs[otelerrors.ReasonsCountKey].AsInt64() != 2 {
+		t.Errorf("expected 2 reasons, got %v", attrs[otelerrors.ReasonsCountKey])
+	}
+
+	if attrs["exception.stacktrace"].AsString() == "" {
+		t.Errorf("expected the stack trace")
+	}
+
+	if got := h.count(t, otelerrors.CodeKey.Int(500), otelerrors.InternalCodeKey.String("db_down")); got != 1 {
+		t.Errorf("expected the error to be counted once, got %d", got)
+	}
+}
+
+func TestRecordClientErrorsKeepTheSpanStatus(t *testing.T) {
+	h := newHarness(t)
+
+	_, span := h.tracer.Tracer("test").Start(context.Background(), "call")
+	h.recorder.Record(span, errors.New(nil, errors.ErrorCode(codes.NotFound)))
+	h.recorder.Record(span, errors.New(nil, errors.ErrorCode(codes.OK)))
+	h.recorder.Record(span, nil)
+	span.End()
+
+	stub := h.span(t)
+	if stub.Status.Code != otelcodes.Unset {
+		t.Errorf("expected the span status to be left unset, got %v", stub.Status)
+	}
+
+	if len(stub.Events) != 1 {
+		t.Errorf("expected only the client error to be recorded, got %v", stub.Events)
+	}
+
+	if got := h.count(t, otelerrors.CodeKey.Int(404)); got != 1 {
+		t.Errorf("expected the client error to be counted once, got %d", got)
+	}
+
+	if got := h.count(t, otelerrors.CodeKey.Int(200)); got != 0 {
+		t.Errorf("expected the non-error code not to be counted, got %d", got)
+	}
+}
+
+func TestRecordPlainErrors(t *testing.T) {
+	h := newHarness(t)
+
+	_, span := h.tracer.Tracer("test").Start(context.Background(), "call")
+	h.recorder.Record(span, context.Canceled)
+	span.End()
+
+	stub := h.span(t)
+	if stub.Status.Code != otelcodes.Error {
+		t.Errorf("expected the plain error to be a server error, got %v", stub.Status)
+	}
+
+	if _, ok := attributesOf(stub.Events[0].Attributes)["exception.stacktrace"]; ok {
+		t.Errorf("expected no stack trace for an error without local frames")
+	}
+
+	if got := h.count(t, otelerrors.CodeKey.Int(500)); got != 1 {
+		t.Errorf("expected the plain error to be counted as 500, got %d", got)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   codes.Code
+		status otelcodes.Code
+	}{
+		{"client error", codes.NotFound, otelcodes.Unset},
+		{"server error", codes.InternalServerError, otelcodes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness(t)
+
+			ctx, span := h.tracer.Tracer("test").Start(context.Background(), "call")
+			_, err := h.recorder.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+				// the frames of the errors sent as statuses don't reach the interceptor
+				return nil, errors.New(nil, errors.ErrorCode(tt.code)).GRPCStatus().Err()
+			})
+			span.End()
+
+			if err == nil {
+				t.Fatalf("expected the error of the handler to be returned")
+			}
+
+			stub := h.span(t)
+			if stub.Status.Code != tt.status || len(stub.Events) != 1 {
+				t.Fatalf("expected the error to be recorded on the span of the context, got %v", stub.Status)
+			}
+
+			if _, ok := attributesOf(stub.Events[0].Attributes)["exception.stacktrace"]; ok {
+				t.Errorf("expected no stack trace for the frames of the interceptor")
+			}
+
+			if got := h.count(t, otelerrors.CodeKey.Int(tt.code.HttpCode())); got != 1 {
+				t.Errorf("expected the error to be counted once, got %d", got)
+			}
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	h := newHarness(t)
+
+	ctx, span := h.tracer.Tracer("test").Start(context.Background(), "call")
+	h.recorder.UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil, func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return errors.New(nil, errors.ErrorCode(codes.NotFound)).GRPCStatus().Err()
+	})
+	span.End()
+
+	if stub := h.span(t); stub.Status.Code != otelcodes.Error {
+		t.Errorf("expected the client errors to set the status of the client spans, got %v", stub.Status)
+	}
+}
+
+func TestTraceContextProvider(t *testing.T) {
+	h := newHarness(t)
+
+	ctx, span := h.tracer.Tracer("test").Start(context.Background(), "call")
+	defer span.End()
+
+	tc, ok := otelerrors.TraceContextProvider().TraceContext(ctx)
+	if !ok || tc.TraceID != span.SpanContext().TraceID().String() || tc.SpanID != span.SpanContext().SpanID().String() {
+		t.Errorf("expected the IDs of the span, got %+v", tc)
+	}
+}
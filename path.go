@@ -0,0 +1,312 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+type PathStyle int
+
+const (
+	// DottedPathStyle renders paths as `items.2.name`
+	DottedPathStyle PathStyle = iota
+	// JSONPointerPathStyle renders paths as `/items/2/name` (RFC 6901)
+	JSONPointerPathStyle
+	// FieldMaskPathStyle renders paths as `items[2].name`, keys containing separators are wrapped in backticks
+	FieldMaskPathStyle
+)
+
+type pathElement struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// FieldPath is a structured path to a (possibly nested) field of a request payload.
+// It is immutable, every builder method returns a new path.
+type FieldPath struct {
+	elements []pathElement
+}
+
+// Path creates a new field path from the given keys.
+// e.g. Path("items").Index(2).Key("name")
+func Path(keys ...string) FieldPath {
+	p := FieldPath{}
+	for _, key := range keys {
+		p = p.Key(key)
+	}
+
+	return p
+}
+
+func (p FieldPath) with(el pathElement) FieldPath {
+	elements := make([]pathElement, len(p.elements), len(p.elements)+1)
+	copy(elements, p.elements)
+
+	return FieldPath{elements: append(elements, el)}
+}
+
+func (p FieldPath) Key(key string) FieldPath {
+	return p.with(pathElement{key: key})
+}
+
+func (p FieldPath) Index(idx int) FieldPath {
+	return p.with(pathElement{index: idx, isIndex: true})
+}
+
+func (p FieldPath) Len() int {
+	return len(p.elements)
+}
+
+func (p FieldPath) IsEmpty() bool {
+	return len(p.elements) == 0
+}
+
+// Segments returns the path elements as strings, indexes are converted with strconv.Itoa
+func (p FieldPath) Segments() []string {
+	segments := make([]string, len(p.elements))
+	for i, el := range p.elements {
+		segments[i] = el.String()
+	}
+
+	return segments
+}
+
+func (p FieldPath) Equal(other FieldPath) bool {
+	if len(p.elements) != len(other.elements) {
+		return false
+	}
+
+	for i, el := range p.elements {
+		if el != other.elements[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p FieldPath) Dotted() string {
+	return strings.Join(p.Segments(), ".")
+}
+
+func (p FieldPath) JSONPointer() string {
+	if len(p.elements) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, el := range p.elements {
+		sb.WriteByte('/')
+		sb.WriteString(jsonPointerEscaper.Replace(el.String()))
+	}
+
+	return sb.String()
+}
+
+func (p FieldPath) FieldMask() string {
+	var sb strings.Builder
+	for i, el := range p.elements {
+		if el.isIndex {
+			sb.WriteByte('[')
+			sb.WriteString(strconv.Itoa(el.index))
+			sb.WriteByte(']')
+			continue
+		}
+
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+
+		if strings.ContainsAny(el.key, ".[]`") {
+			sb.WriteByte('`')
+			sb.WriteString(strings.ReplaceAll(el.key, "`", "``"))
+			sb.WriteByte('`')
+		} else {
+			sb.WriteString(el.key)
+		}
+	}
+
+	return sb.String()
+}
+
+// Format renders the path in the given style
+func (p FieldPath) Format(style PathStyle) string {
+	switch style {
+	case JSONPointerPathStyle:
+		return p.JSONPointer()
+	case FieldMaskPathStyle:
+		return p.FieldMask()
+	default:
+		return p.Dotted()
+	}
+}
+
+// String renders the path in the style of the default factory, see SetPathStyle and ReplaceDefaultFactory
+func (p FieldPath) String() string {
+	return p.Format(defaultFactory.Load().pathStyle())
+}
+
+func (el pathElement) String() string {
+	if el.isIndex {
+		return strconv.Itoa(el.index)
+	}
+
+	return el.key
+}
+
+func (p FieldPath) toProto() *ReasonPath {
+	pb := &ReasonPath{Segments: make([]*ReasonPath_Segment, len(p.elements))}
+	for i, el := range p.elements {
+		if el.isIndex {
+			pb.Segments[i] = &ReasonPath_Segment{Kind: &ReasonPath_Segment_Index{Index: int64(el.index)}}
+		} else {
+			pb.Segments[i] = &ReasonPath_Segment{Kind: &ReasonPath_Segment_Key{Key: el.key}}
+		}
+	}
+
+	return pb
+}
+
+func pathFromProto(pb *ReasonPath) FieldPath {
+	p := FieldPath{elements: make([]pathElement, 0, len(pb.GetSegments()))}
+	for _, segment := range pb.GetSegments() {
+		if idx, ok := segment.GetKind().(*ReasonPath_Segment_Index); ok {
+			p.elements = append(p.elements, pathElement{index: int(idx.Index), isIndex: true})
+		} else {
+			p.elements = append(p.elements, pathElement{key: segment.GetKey()})
+		}
+	}
+
+	return p
+}
+
+func pathsToProto(paths map[string]FieldPath) map[string]*ReasonPath {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	pb := make(map[string]*ReasonPath, len(paths))
+	for key, path := range paths {
+		pb[key] = path.toProto()
+	}
+
+	return pb
+}
+
+func pathsFromProto(pb map[string]*ReasonPath) map[string]FieldPath {
+	if len(pb) == 0 {
+		return nil
+	}
+
+	paths := make(map[string]FieldPath, len(pb))
+	for key, path := range pb {
+		paths[key] = pathFromProto(path)
+	}
+
+	return paths
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// ParsePath parses a rendered key back into a path. The style is detected from the key:
+// keys starting with `/` are JSON pointers, keys containing `[` or a backtick are field masks,
+// everything else is dotted. Numeric segments are treated as indexes.
+func ParsePath(key string) FieldPath {
+	switch {
+	case key == "":
+		return FieldPath{}
+	case strings.HasPrefix(key, "/"):
+		return parseJSONPointer(key)
+	case strings.ContainsAny(key, "[`"):
+		return parseFieldMask(key)
+	default:
+		return parseSegments(strings.Split(key, "."))
+	}
+}
+
+func parseSegments(segments []string) FieldPath {
+	p := FieldPath{elements: make([]pathElement, 0, len(segments))}
+	for _, segment := range segments {
+		p.elements = append(p.elements, parseSegment(segment))
+	}
+
+	return p
+}
+
+func parseSegment(segment string) pathElement {
+	if idx, err := strconv.Atoi(segment); err == nil && idx >= 0 && strconv.Itoa(idx) == segment {
+		return pathElement{index: idx, isIndex: true}
+	}
+
+	return pathElement{key: segment}
+}
+
+func parseJSONPointer(key string) FieldPath {
+	segments := strings.Split(key[1:], "/")
+	for i, segment := range segments {
+		segments[i] = jsonPointerUnescaper.Replace(segment)
+	}
+
+	return parseSegments(segments)
+}
+
+func parseFieldMask(key string) FieldPath {
+	p := FieldPath{}
+
+	var current strings.Builder
+	pending := false
+	flush := func() {
+		if pending {
+			p.elements = append(p.elements, pathElement{key: current.String()})
+		}
+
+		current.Reset()
+		pending = false
+	}
+
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; c {
+		case '`':
+			// quoted key, a doubled backtick is an escaped backtick
+			for i++; i < len(key); i++ {
+				if key[i] == '`' {
+					if i+1 < len(key) && key[i+1] == '`' {
+						current.WriteByte('`')
+						i++
+						continue
+					}
+
+					break
+				}
+
+				current.WriteByte(key[i])
+			}
+
+			pending = true
+		case '.':
+			flush()
+		case '[':
+			flush()
+
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				current.WriteString(key[i:])
+				pending = true
+				i = len(key)
+				continue
+			}
+
+			p.elements = append(p.elements, parseSegment(key[i+1:i+end]))
+			i += end
+		default:
+			current.WriteByte(c)
+			pending = true
+		}
+	}
+
+	flush()
+
+	return p
+}
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRehydratedReasonKeys(t *testing.T) {
+	src := New(nil).
+		AddReason("tags[]", SimpleReason("invalid")).
+		AddReason("/etc", SimpleReason("invalid")).
+		AddReason("x`y", SimpleReason("invalid")).
+		AddFieldReason(Path("items").Index(0).Key("name"), SimpleReason("required"))
+
+	want := []FieldReasons{
+		{Key: "tags[]"},
+		{Key: "/etc"},
+		{Key: "x`y"},
+		{Key: "items.0.name", FieldPath: true},
+	}
+
+	ordered := New(src.GRPCStatus().Err()).GetOrderedReasons()
+	if len(ordered) != len(want) {
+		t.Fatalf("expected %d keys, got %d", len(want), len(ordered))
+	}
+
+	for i, fr := range ordered {
+		if fr.Key != want[i].Key || fr.FieldPath != want[i].FieldPath {
+			t.Errorf("key %d: expected %q (field path %t), got %q (field path %t)", i, want[i].Key, want[i].FieldPath, fr.Key, fr.FieldPath)
+		}
+	}
+}
+
+func TestRehydratedReasonKeysV1(t *testing.T) {
+	details := &ErrorDetails{
+		Reasons: map[string][]Reason{
+			"tags[]":        {SimpleReason("invalid")},
+			"/items/0/name": {SimpleReason("required")},
+		},
+		ReasonKeys: []string{"tags[]", "/items/0/name"},
+		FieldPaths: map[string]FieldPath{"/items/0/name": Path("items").Index(0).Key("name")},
+	}
+
+	detail, err := marshalDetailsV1(details)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := status.New(codes.BadRequest.GrpcCode(), "").WithDetails(detail)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := New(st.Err())
+	if !got.HasReasons("tags[]", "items.0.name") {
+		t.Errorf("expected the free-form key to be kept and the JSON pointer to be normalized, got %v", got.GetReasons())
+	}
+}
+
+func TestRehydratedReasonKeysKeepTheSegments(t *testing.T) {
+	paths := []FieldPath{
+		Path("emails").Key("a.b@x.com"),
+		Path("a[0]"),
+		Path("a/b", "c~d").Index(3),
+		Path("x`y", "1"),
+	}
+
+	for _, version := range []DetailsVersion{DetailsV1, DetailsV2} {
+		src := NewFactory(WithDetailsVersion(version)).New(nil)
+		for _, path := range paths {
+			src.AddFieldReason(path, SimpleReason("invalid"))
+		}
+
+		for _, style := range []PathStyle{DottedPathStyle, JSONPointerPathStyle, FieldMaskPathStyle} {
+			ordered := NewFactory(WithPathStyle(style)).New(src.GRPCStatus().Err()).GetOrderedReasons()
+			if len(ordered) != len(paths) {
+				t.Fatalf("v%d style %d: expected %d keys, got %d", version, style, len(paths), len(ordered))
+			}
+
+			for i, fr := range ordered {
+				if !fr.FieldPath || !fr.Path.Equal(paths[i]) || fr.Key != paths[i].Format(style) {
+					t.Errorf("v%d style %d: expected %q, got %q with the path %v", version, style, paths[i].Format(style), fr.Key, fr.Path.Segments())
+				}
+			}
+		}
+	}
+}
+
+func TestPathStyles(t *testing.T) {
+	cases := []struct {
+		path                       FieldPath
+		dotted, pointer, fieldMask string
+	}{
+		{Path(), "", "", ""},
+		{Path("items").Index(2).Key("name"), "items.2.name", "/items/2/name", "items[2].name"},
+		{Path("a/b", "c~d"), "a/b.c~d", "/a~1b/c~0d", "a/b.c~d"},
+		{Path("~1"), "~1", "/~01", "~1"},
+		{Path("emails", "a.b@x.com"), "emails.a.b@x.com", "/emails/a.b@x.com", "emails.`a.b@x.com`"},
+		{Path("a[0]", "x`y"), "a[0].x`y", "/a[0]/x`y", "`a[0]`.`x``y`"},
+		{Path("matrix").Index(0).Index(1), "matrix.0.1", "/matrix/0/1", "matrix[0][1]"},
+	}
+
+	for _, tc := range cases {
+		if got := tc.path.Dotted(); got != tc.dotted {
+			t.Errorf("Dotted() = %q, expected %q", got, tc.dotted)
+		}
+
+		if got := tc.path.JSONPointer(); got != tc.pointer {
+			t.Errorf("JSONPointer() = %q, expected %q", got, tc.pointer)
+		}
+
+		if got := tc.path.FieldMask(); got != tc.fieldMask {
+			t.Errorf("FieldMask() = %q, expected %q", got, tc.fieldMask)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	// every path survives the styles which can represent it without ambiguity
+	paths := []FieldPath{
+		Path("items").Index(2).Key("name"),
+		Path("a/b", "c~d").Index(0),
+		Path("~01"),
+		Path("emails", "a.b@x.com"),
+		Path("a[0]", "x`y"),
+		Path("matrix").Index(0).Index(1),
+	}
+
+	for _, path := range paths {
+		for _, style := range []PathStyle{JSONPointerPathStyle, FieldMaskPathStyle} {
+			key := path.Format(style)
+			if got := ParsePath(key); !got.Equal(path) {
+				t.Errorf("ParsePath(%q) = %v, expected %v", key, got.Segments(), path.Segments())
+			}
+		}
+	}
+
+	if got := ParsePath("items.2.name"); !got.Equal(Path("items").Index(2).Key("name")) {
+		t.Errorf("expected the dotted key to be parsed, got %v", got.Segments())
+	}
+
+	if got := ParsePath(""); !got.IsEmpty() {
+		t.Errorf("expected an empty path, got %v", got.Segments())
+	}
+}
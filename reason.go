@@ -4,15 +4,30 @@ type Reason interface {
 	ToHashMap() map[string]interface{}
 }
 
+// FieldReasons holds the reasons added for a single key
+type FieldReasons struct {
+	Key     string
+	Reasons []Reason
+	// FieldPath is set when the key was rendered from Path, the other keys are kept as is
+	FieldPath bool
+	Path      FieldPath
+}
+
 type reason struct {
 	Type      string     `json:"type"`
 	Info      *string    `json:"info"`
 	Attribute *Attribute `json:"attribute"`
+	// Extra holds keys of custom reasons decoded from DetailedErrorResponseV2
+	Extra map[string]interface{} `json:"-"`
 }
 
 func (r reason) ToHashMap() map[string]interface{} {
 	d := make(map[string]interface{})
 
+	for k, v := range r.Extra {
+		d[k] = v
+	}
+
 	d["type"] = r.Type
 
 	if r.Info != nil {
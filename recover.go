@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+)
+
+// PanicValueKey is the metadata key of the recovered value, it's only logged and never sent to the clients
+const PanicValueKey = "panic.value"
+
+const recoveredMessage = "internal server error"
+
+// Recover converts a panic into a reportable DetailedError, it must be deferred directly.
+//
+//	func handle() (err error) {
+//		defer errors.Recover(&err)
+//		...
+//	}
+//
+// The panic isn't recovered when target is nil, there would be nowhere to return the error.
+func Recover(target *error) {
+	if target == nil {
+		return
+	}
+
+	if r := recover(); r != nil {
+		*target = recovered(context.Background(), r)
+	}
+}
+
+// recovered must be called from the deferred function, so the stack of the panic is still available
+func recovered(ctx context.Context, value any) DetailedError {
+	f := defaultFactory.Load()
+	id, generated := f.seedID(ctx)
+
+	de := &err{
+		id:          id,
+		idGenerated: generated,
+		trace:       f.traceContextFrom(ctx),
+		message:     recoveredMessage,
+		frames:      panicFrames(f.stackTraceDepth()),
+		code:        codes.InternalServerError,
+		reportable:  true,
+		ctx:         ctx,
+	}
+
+	if e, ok := value.(error); ok {
+		de.original = e
+	}
+
+	de.AddMetadataWithVisibility(PanicValueKey, fmt.Sprintf("%v", value), VisibilityLogOnly)
+
+	return de
+}
+
+// panicFrames returns the stack starting from the function which panicked,
+// the frames of the deferred functions and the runtime are dropped
+func panicFrames(depth int) []frame {
+	callers := make([]uintptr, depth)
+	length := runtime.Callers(3, callers[:])
+	callers = callers[:length]
+
+	for i, pc := range callers {
+		if fn := runtime.FuncForPC(pc - 1); fn == nil || fn.Name() != "runtime.gopanic" {
+			continue
+		}
+
+		callers = callers[i+1:]
+		// runtime errors panic through the runtime. e.g. runtime.panicmem, runtime.sigpanic
+		for len(callers) > 0 {
+			fn := runtime.FuncForPC(callers[0] - 1)
+			if fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+				break
+			}
+
+			callers = callers[1:]
+		}
+
+		break
+	}
+
+	frames := make([]frame, len(callers))
+	for i, pc := range callers {
+		frames[i] = frame(pc)
+	}
+
+	return frames
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+)
+
+func TestRecover(t *testing.T) {
+	e := func() (e error) {
+		defer Recover(&e)
+		panic("boom")
+	}()
+
+	de, ok := e.(DetailedError)
+	if !ok {
+		t.Fatalf("expected a DetailedError, got %T", e)
+	}
+
+	if de.GetCode() != codes.InternalServerError || !de.IsReportable() || de.GetMetadata()[PanicValueKey] != "boom" {
+		t.Errorf("expected a reportable internal error with the panic value, got %v", de.GetMetadata())
+	}
+}
+
+func TestRecoverWithoutTargetKeepsPanicking(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected the panic to propagate, got %v", r)
+		}
+	}()
+
+	func() {
+		defer Recover(nil)
+		panic("boom")
+	}()
+
+	t.Errorf("expected the panic to propagate")
+}
@@ -0,0 +1,370 @@
+package errors
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Redactor removes PII and secrets from the messages, metadata and headers
+// before they're sent over the wire, formatted or logged.
+type Redactor interface {
+	RedactString(s string) string
+	RedactValue(key string, value interface{}) interface{}
+}
+
+type RedactionMode int
+
+const (
+	// MaskRedaction replaces the sensitive values with the mask
+	MaskRedaction RedactionMode = iota
+	// HashRedaction replaces the sensitive values with a keyed hash, so equal values can still be correlated
+	HashRedaction
+)
+
+const DefaultRedactionMask = "[REDACTED]"
+
+// DefaultRedactedKeys are matched case-insensitively against the whole segments of the key,
+// a key is redacted if any run of its segments is one of them. e.g. "X-Api-Key", "user_password"
+var DefaultRedactedKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"authorization",
+	"apikey",
+	"cookie",
+	"session",
+	"cardnumber",
+	"cvv",
+	"ssn",
+}
+
+// cardNumberPattern matches 13 to 19 digits optionally separated by spaces or dashes,
+// the matches are only redacted if they pass the Luhn check
+var cardNumberPattern = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+
+func isLuhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+var DefaultRedactedPatterns = []*regexp.Regexp{
+	// email addresses
+	regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+	// bearer and basic authorization values
+	regexp.MustCompile(`(?i)\b(?:bearer|basic)\s+[a-zA-Z0-9._~+/\-]+=*`),
+	// JSON web tokens
+	regexp.MustCompile(`\beyJ[a-zA-Z0-9_\-]+\.[a-zA-Z0-9_\-]+\.[a-zA-Z0-9_\-]+`),
+	cardNumberPattern,
+	// AWS access key ids
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`),
+}
+
+type redactorOptions struct {
+	keys     []string
+	patterns []*regexp.Regexp
+	mode     RedactionMode
+	mask     string
+	hashKey  []byte
+}
+
+type RedactorOption func(*redactorOptions)
+
+// RedactKeys adds keys to the deny-list
+func RedactKeys(keys ...string) RedactorOption {
+	return func(o *redactorOptions) {
+		o.keys = append(o.keys, keys...)
+	}
+}
+
+// RedactPatterns adds patterns, every match in a string value is redacted
+func RedactPatterns(patterns ...*regexp.Regexp) RedactorOption {
+	return func(o *redactorOptions) {
+		o.patterns = append(o.patterns, patterns...)
+	}
+}
+
+// WithoutDefaultRedactions removes DefaultRedactedKeys and DefaultRedactedPatterns,
+// the options after this one are still applied
+func WithoutDefaultRedactions() RedactorOption {
+	return func(o *redactorOptions) {
+		o.keys = nil
+		o.patterns = nil
+	}
+}
+
+func RedactWithMask(mask string) RedactorOption {
+	return func(o *redactorOptions) {
+		o.mode = MaskRedaction
+		o.mask = mask
+	}
+}
+
+// RedactWithHash replaces the values with the first 12 hex characters of HMAC-SHA256 of the value
+func RedactWithHash(key []byte) RedactorOption {
+	return func(o *redactorOptions) {
+		o.mode = HashRedaction
+		o.hashKey = key
+	}
+}
+
+type redactor struct {
+	keys     []string
+	patterns []*regexp.Regexp
+	mode     RedactionMode
+	mask     string
+	hashKey  []byte
+}
+
+// NewRedactor creates a redactor with DefaultRedactedKeys and DefaultRedactedPatterns
+func NewRedactor(opts ...RedactorOption) Redactor {
+	o := &redactorOptions{
+		keys:     append([]string(nil), DefaultRedactedKeys...),
+		patterns: append([]*regexp.Regexp(nil), DefaultRedactedPatterns...),
+		mode:     MaskRedaction,
+		mask:     DefaultRedactionMask,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &redactor{
+		keys:     make([]string, len(o.keys)),
+		patterns: o.patterns,
+		mode:     o.mode,
+		mask:     o.mask,
+		hashKey:  o.hashKey,
+	}
+
+	for i, key := range o.keys {
+		r.keys[i] = normalizeRedactedKey(key)
+	}
+
+	return r
+}
+
+var redactedKeyNormalizer = strings.NewReplacer("-", "", "_", "", ".", "", " ", "")
+
+func normalizeRedactedKey(key string) string {
+	return redactedKeyNormalizer.Replace(strings.ToLower(key))
+}
+
+// redactedKeySegments splits the key on "-", "_", ".", spaces and the camelCase boundaries,
+// the segments are lowercased. e.g. "X-Api-Key" and "xApiKey" both become [x api key]
+func redactedKeySegments(key string) []string {
+	segments := make([]string, 0)
+	for _, part := range strings.FieldsFunc(key, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' '
+	}) {
+		start := 0
+		runes := []rune(part)
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+				segments = append(segments, strings.ToLower(string(runes[start:i])))
+				start = i
+			}
+		}
+
+		segments = append(segments, strings.ToLower(string(runes[start:])))
+	}
+
+	return segments
+}
+
+// isDenied matches the deny-list against whole segments of the key, a denied key spanning
+// several segments matches them joined. e.g. "apikey" matches "X-Api-Key", but "ssn" doesn't match "business_name"
+func (r *redactor) isDenied(key string) bool {
+	segments := redactedKeySegments(key)
+	for i := range segments {
+		joined := ""
+		for _, segment := range segments[i:] {
+			joined += segment
+			for _, denied := range r.keys {
+				if denied != "" && joined == denied {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (r *redactor) replacement(value string) string {
+	if r.mode != HashRedaction {
+		return r.mask
+	}
+
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+
+	return "[sha256:" + hex.EncodeToString(mac.Sum(nil))[:12] + "]"
+}
+
+func (r *redactor) RedactString(s string) string {
+	for _, pattern := range r.patterns {
+		s = pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if pattern == cardNumberPattern && !isLuhnValid(match) {
+				return match
+			}
+
+			return r.replacement(match)
+		})
+	}
+
+	return s
+}
+
+// RedactValue redacts the whole value if the key is denied, otherwise it redacts the strings recursively.
+// Values are normalized first, so structs and other types are redacted through their JSON representation.
+func (r *redactor) RedactValue(key string, value interface{}) interface{} {
+	if r.isDenied(key) {
+		if value == nil {
+			return nil
+		}
+
+		return r.replacement(fmt.Sprintf("%v", value))
+	}
+
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		// the marshalers will apply the metadata policy to it
+		return value
+	}
+
+	switch v := normalized.(type) {
+	case string:
+		return r.RedactString(v)
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.RedactValue(key, item)
+		}
+
+		return v
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = r.RedactValue(k, item)
+		}
+
+		return v
+	default:
+		return normalized
+	}
+}
+
+type noopRedactor struct{}
+
+func (noopRedactor) RedactString(s string) string {
+	return s
+}
+
+func (noopRedactor) RedactValue(_ string, value interface{}) interface{} {
+	return value
+}
+
+// NoopRedactor disables the redaction
+var NoopRedactor Redactor = noopRedactor{}
+
+var activeRedactor = NewRedactor()
+var activeRedactorSetOnce sync.Once
+
+// SetRedactor sets the redactor applied before `GRPCStatus`, `Send`, formatting and logging
+func SetRedactor(r Redactor) {
+	if r == nil {
+		return
+	}
+
+	activeRedactorSetOnce.Do(func() {
+		activeRedactor = r
+	})
+}
+
+func redactMap(r Redactor, md map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(md))
+	for k, v := range md {
+		redacted[k] = r.RedactValue(k, v)
+	}
+
+	return redacted
+}
+
+func redactMD(r Redactor, md metadata.MD) metadata.MD {
+	redacted := make(metadata.MD, len(md))
+	for k, values := range md {
+		list := make([]string, len(values))
+		for i, v := range values {
+			if s, ok := r.RedactValue(k, v).(string); ok {
+				list[i] = s
+			} else {
+				list[i] = v
+			}
+		}
+
+		redacted[k] = list
+	}
+
+	return redacted
+}
+
+// redactedReason holds the redacted hash map of a reason
+type redactedReason map[string]interface{}
+
+func (r redactedReason) ToHashMap() map[string]interface{} {
+	return r
+}
+
+// redactReasons redacts the hash maps of the reasons, the info and the attribute values may hold user input
+func redactReasons(r Redactor, reasons []Reason) []Reason {
+	if len(reasons) == 0 {
+		return reasons
+	}
+
+	redacted := make([]Reason, len(reasons))
+	for i, reason := range reasons {
+		hm := reason.ToHashMap()
+		d := make(redactedReason, len(hm))
+		for k, v := range hm {
+			d[k] = r.RedactValue(k, v)
+		}
+
+		redacted[i] = d
+	}
+
+	return redacted
+}
+
+func redactKeyedReasons(r Redactor, reasons map[string][]Reason) map[string][]Reason {
+	redacted := make(map[string][]Reason, len(reasons))
+	for key, list := range reasons {
+		redacted[key] = redactReasons(r, list)
+	}
+
+	return redacted
+}
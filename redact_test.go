@@ -0,0 +1,114 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+type recordingStream struct {
+	headers  metadata.MD
+	trailers metadata.MD
+}
+
+func (s *recordingStream) Method() string {
+	return "/test.Service/Method"
+}
+
+func (s *recordingStream) SetHeader(md metadata.MD) error {
+	s.headers = metadata.Join(s.headers, md)
+	return nil
+}
+
+func (s *recordingStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *recordingStream) SetTrailer(md metadata.MD) error {
+	s.trailers = metadata.Join(s.trailers, md)
+	return nil
+}
+
+func TestRedactedValuesNeverReachTheWire(t *testing.T) {
+	const (
+		email  = "bob@example.com"
+		secret = "hunter2-secret-value"
+	)
+
+	for _, version := range []DetailsVersion{DetailsV1, DetailsV2, DetailsV1AndV2} {
+		t.Run(fmt.Sprintf("version %d", version), func(t *testing.T) {
+			stream := &recordingStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+			info := "contact " + email
+			f := NewFactory(WithRedactor(NewRedactor()), WithDetailsVersion(version))
+			de := f.New(nil, Message("failed for "+email)).
+				Context(ctx).
+				IncludeMetadata().
+				AddMetadataWithVisibility("password", secret, VisibilityPublic).
+				AddMetadataWithVisibility("contact", email, VisibilityPublic).
+				AddReason("email", NewReason("invalid", &info, &Attribute{
+					Value: email,
+					Extra: map[string]any{"api_key": secret},
+				})).
+				AddGlobalReason(NewReason("denied", &info, nil)).
+				AddHeader("x-api-key", secret).
+				AddTrailer("x-contact", email)
+
+			wire, err := proto.Marshal(de.GRPCStatus().Proto())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_ = de.Send()
+
+			rendered := [][]byte{wire, []byte(fmt.Sprintf("%+v", de))}
+			for _, md := range []metadata.MD{stream.headers, stream.trailers} {
+				rendered = append(rendered, []byte(fmt.Sprint(md)))
+			}
+
+			for _, out := range rendered {
+				for _, value := range []string{email, secret} {
+					if bytes.Contains(out, []byte(value)) {
+						t.Errorf("expected %q to be redacted from %q", value, out)
+					}
+				}
+			}
+
+			if !bytes.Contains(wire, []byte(DefaultRedactionMask)) {
+				t.Errorf("expected the mask on the wire")
+			}
+		})
+	}
+}
+
+func TestRedactorMatchesWholeSegments(t *testing.T) {
+	r := NewRedactor().(*redactor)
+
+	cases := map[string]bool{
+		"password":      true,
+		"user_password": true,
+		"X-Api-Key":     true,
+		"xApiKey":       true,
+		"api.key":       true,
+		"accessToken":   true,
+		"card-number":   true,
+		"ssn":           true,
+		"business_name": false,
+		"classname":     false,
+		"tokenizer":     false,
+		"keyboard":      false,
+		"name":          false,
+	}
+
+	for key, denied := range cases {
+		if got := r.isDenied(key); got != denied {
+			t.Errorf("isDenied(%q) = %v, expected %v", key, got, denied)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package errors
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterKey is the header and trailer the retry delay is sent with by `Send`, in seconds
+const RetryAfterKey = "retry-after"
+
+// retryableCodes are retryable unless the error is explicitly marked otherwise
+var retryableCodes = map[codes.Code]bool{
+	codes.Find(http.StatusRequestTimeout):     true,
+	codes.Find(http.StatusTooManyRequests):    true,
+	codes.Find(http.StatusBadGateway):         true,
+	codes.Find(http.StatusServiceUnavailable): true,
+	codes.Find(http.StatusGatewayTimeout):     true,
+}
+var retryableCodesSetOnce sync.Once
+
+// SetRetryableCodes replaces the default retryability table
+func SetRetryableCodes(list ...codes.Code) {
+	retryableCodesSetOnce.Do(func() {
+		retryableCodes = make(map[codes.Code]bool, len(list))
+		for _, code := range list {
+			retryableCodes[code] = true
+		}
+	})
+}
+
+// IsRetryable reports whether the error is marked as retryable, otherwise whether its code is retryable.
+// Errors received from another service are rehydrated with `New`.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return New(err, CallerOffset(1)).IsRetryable()
+}
+
+// RetryDelay returns the delay requested by the error, it's zero when the error doesn't request one
+func RetryDelay(err error) time.Duration {
+	if err == nil {
+		return 0
+	}
+
+	d, _ := New(err, CallerOffset(1)).GetRetryAfter()
+
+	return d
+}
+
+func formatRetryAfter(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
+
+// parseRetryAfter only accepts the delay in seconds, the HTTP date form isn't sent by `Send`
+func parseRetryAfter(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+func retryInfoFromDetail(detail any) (time.Duration, bool) {
+	info, ok := detail.(*errdetails.RetryInfo)
+	if !ok || info.GetRetryDelay() == nil {
+		return 0, false
+	}
+
+	return info.GetRetryDelay().AsDuration(), true
+}
+
+func retryInfo(d time.Duration) *errdetails.RetryInfo {
+	return &errdetails.RetryInfo{
+		RetryDelay: durationpb.New(d),
+	}
+}
+
+const (
+	// RetryAttemptsKey is the metadata key of the number of the attempts made by `Retry`
+	RetryAttemptsKey = "retry.attempts"
+	// RetryLastCauseKey is the metadata key of the message of the last error returned by the retried function
+	RetryLastCauseKey = "retry.last_cause"
+)
+
+// Clock is used by `Retry` to wait between the attempts, it can be replaced to test without sleeping
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt, a single attempt is made when it is lower than 2
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomizes the backoff by the fraction in both directions. e.g. 0.2 is ±20%
+	Jitter float64
+	// RetryableCodes replaces the retryability table for this policy, the errors marked with
+	// Retryable or NotRetryable are classified by their mark
+	RetryableCodes []codes.Code
+	// Clock defaults to the system clock
+	Clock Clock
+	// Rand returns a number in [0, 1) for the jitter, it defaults to math/rand
+	Rand func() float64
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+func (p *RetryPolicy) isRetryable(de DetailedError) bool {
+	if p.RetryableCodes == nil {
+		return de.IsRetryable()
+	}
+
+	// the explicit mark takes precedence over the table
+	if e, ok := de.(*err); ok && e.retryable != nil {
+		return *e.retryable
+	}
+
+	for _, code := range p.RetryableCodes {
+		if code == de.GetCode() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the delay after the attempt, the attempts start from 1
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		random := rand.Float64
+		if p.Rand != nil {
+			random = p.Rand
+		}
+
+		d += d * p.Jitter * (2*random() - 1)
+	}
+
+	return time.Duration(d)
+}
+
+// Retry calls fn until it succeeds, returns a non-retryable error or the attempts are exhausted.
+// The delay requested by the error with RetryAfter, or by the server with the retry-after header or trailer,
+// replaces the backoff. The returned error is the last error rehydrated with `New`, annotated with
+// RetryAttemptsKey and RetryLastCauseKey. When the context is done, the context error is returned instead.
+// The call options passed to fn capture the header and the trailer of the response, fn passes them to the
+// gRPC call, so the retry-after and the error ID sent by the server are rehydrated. e.g.
+//
+//	errors.Retry(ctx, policy, func(ctx context.Context, opts ...grpc.CallOption) error {
+//		_, err := client.Get(ctx, req, opts...)
+//		return err
+//	})
+func Retry(ctx context.Context, policy *RetryPolicy, fn func(ctx context.Context, opts ...grpc.CallOption) error) error {
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	clock := policy.Clock
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	var last DetailedError
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			return retryCancelled(ctx, last, attempt-1)
+		}
+
+		header, trailer := make(metadata.MD), make(metadata.MD)
+		e := fn(ctx, grpc.Header(&header), grpc.Trailer(&trailer))
+		if e == nil {
+			return nil
+		}
+
+		last = New(e, Context(ctx), Headers(orEmptyMD(header)), Trailers(orEmptyMD(trailer)))
+		if attempt >= policy.MaxAttempts || !policy.isRetryable(last) {
+			return retryExhausted(ctx, last, attempt)
+		}
+
+		delay, ok := last.GetRetryAfter()
+		if !ok {
+			delay = policy.backoff(attempt)
+		}
+
+		// waiting is pointless if the deadline is reached before the next attempt
+		if deadline, ok := ctx.Deadline(); ok && clock.Now().Add(delay).After(deadline) {
+			return retryExhausted(ctx, last, attempt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return retryCancelled(ctx, last, attempt)
+		case <-clock.After(delay):
+		}
+	}
+}
+
+// orEmptyMD replaces the nil metadata left by a call failed before the response
+func orEmptyMD(md metadata.MD) metadata.MD {
+	if md == nil {
+		return make(metadata.MD)
+	}
+
+	return md
+}
+
+// retryExhausted wraps the last error, so the error returned by fn is never modified
+func retryExhausted(ctx context.Context, last DetailedError, attempts int) DetailedError {
+	opts := []ErrorOption{
+		Message(last.Error()),
+		ErrorCode(last.GetCode()),
+		ErrorID(last.ID()),
+		Context(ctx),
+		CallerOffset(1),
+	}
+
+	if code, ok := last.GetInternalCode(); ok {
+		opts = append(opts, InternalCode(code))
+	}
+
+	if last.IsReportable() {
+		opts = append(opts, Reportable())
+	}
+
+	de := New(nil, opts...).
+		Merge(last).
+		AddMetadata(RetryAttemptsKey, attempts).
+		AddMetadata(RetryLastCauseKey, last.Error())
+
+	if e, ok := de.(*err); ok {
+		e.original = last
+	}
+
+	return de
+}
+
+func retryCancelled(ctx context.Context, last DetailedError, attempts int) DetailedError {
+	st := status.FromContextError(ctx.Err())
+	de := New(st.Err(), Message(st.Message()), Context(ctx), CallerOffset(1)).
+		AddMetadata(RetryAttemptsKey, attempts)
+
+	if last != nil {
+		de.AddMetadata(RetryLastCauseKey, last.Error())
+	}
+
+	return de
+}
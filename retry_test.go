@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeClock struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	c.now = c.now.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+func TestRetryUsesTheRetryAfterTrailer(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	policy := DefaultRetryPolicy()
+	policy.Clock = clock
+
+	attempts := 0
+	e := Retry(context.Background(), policy, func(_ context.Context, opts ...grpc.CallOption) error {
+		attempts++
+		for _, opt := range opts {
+			if trailer, ok := opt.(grpc.TrailerCallOption); ok {
+				*trailer.TrailerAddr = metadata.Pairs(RetryAfterKey, "7", ErrorIDTrailerKey, "server-id")
+			}
+		}
+
+		if attempts < 2 {
+			return status.Error(grpccodes.Unavailable, "overloaded")
+		}
+
+		return nil
+	})
+
+	if e != nil {
+		t.Fatalf("expected the second attempt to succeed, got %v", e)
+	}
+
+	if len(clock.delays) != 1 || clock.delays[0] != 7*time.Second {
+		t.Errorf("expected the delay of the trailer, got %v", clock.delays)
+	}
+}
+
+func TestRetryExhaustedWrapsTheLastError(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.Clock = &fakeClock{now: time.Unix(0, 0)}
+	policy.Jitter = 0
+
+	last := New(nil, Message("unavailable"), ErrorCode(codes.ServiceUnavailable), InternalCode("db_down")).Retryable()
+	e := Retry(context.Background(), policy, func(context.Context, ...grpc.CallOption) error {
+		return last
+	})
+
+	var de DetailedError
+	if !stderrors.As(e, &de) {
+		t.Fatalf("expected a DetailedError, got %T", e)
+	}
+
+	if de == last || !stderrors.Is(de, last) {
+		t.Errorf("expected a new error wrapping the last error")
+	}
+
+	if last.HasMetadata(RetryAttemptsKey) || last.HasMetadata(RetryLastCauseKey) {
+		t.Errorf("expected the last error to be left as is, got %v", last.GetMetadata())
+	}
+
+	if de.GetMetadata()[RetryAttemptsKey] != 3 || de.GetMetadata()[RetryLastCauseKey] != "unavailable" {
+		t.Errorf("expected the retry metadata, got %v", de.GetMetadata())
+	}
+
+	if code, _ := de.GetInternalCode(); de.GetCode() != codes.ServiceUnavailable || code != "db_down" || de.ID() != last.ID() {
+		t.Errorf("expected the code, internal code and ID of the last error")
+	}
+}
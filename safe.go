@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// UnsafeArgMask replaces the unsafe arguments in the safe rendering of Newf and Messagef
+const UnsafeArgMask = "‹×›"
+
+// SafeValue marks a format argument as safe, it is kept in the safe rendering of the message
+type SafeValue struct {
+	value interface{}
+}
+
+// Safe marks v as safe to be sent off-box, e.g. constants, enums, counts
+func Safe(v interface{}) SafeValue {
+	return SafeValue{value: v}
+}
+
+func (sv SafeValue) Value() interface{} {
+	return sv.value
+}
+
+func (sv SafeValue) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, fmt.FormatString(s, verb), sv.value)
+}
+
+// unsafeArg is an error as well, so it can be used with %w
+type unsafeArg struct{}
+
+func (unsafeArg) Error() string {
+	return UnsafeArgMask
+}
+
+func (unsafeArg) Format(s fmt.State, _ rune) {
+	io.WriteString(s, UnsafeArgMask)
+}
+
+// sprintfSafe renders the format twice, with every argument and with only the safe arguments
+func sprintfSafe(format string, args ...interface{}) (full string, safe string) {
+	fullArgs := make([]interface{}, len(args))
+	safeArgs := make([]interface{}, len(args))
+
+	for i, arg := range args {
+		if sv, ok := arg.(SafeValue); ok {
+			fullArgs[i] = sv.value
+			safeArgs[i] = sv.value
+		} else {
+			fullArgs[i] = arg
+			safeArgs[i] = unsafeArg{}
+		}
+	}
+
+	// fmt.Errorf supports %w as well
+	return fmt.Errorf(format, fullArgs...).Error(), fmt.Errorf(format, safeArgs...).Error()
+}
+
+// wrappedArg returns the operand(s) of %w, a single operand is returned as is,
+// several operands are returned as the error of fmt.Errorf which unwraps to all of them
+func wrappedArg(format string, args ...interface{}) error {
+	fullArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		if sv, ok := arg.(SafeValue); ok {
+			fullArgs[i] = sv.value
+		} else {
+			fullArgs[i] = arg
+		}
+	}
+
+	switch wrapped := fmt.Errorf(format, fullArgs...).(type) {
+	case interface{ Unwrap() error }:
+		return wrapped.Unwrap()
+	case interface{ Unwrap() []error }:
+		return wrapped.(error)
+	}
+
+	return nil
+}
+
+// Newf creates a new DetailedError with a formatted message. The format arguments are unsafe,
+// unless wrapped with Safe, they're masked in the safe rendering returned by `SafeMessage`.
+// The operand of %w is kept as the original error, so errors.Is and errors.As see through it.
+func Newf(format string, args ...interface{}) DetailedError {
+	de := New(nil, Messagef(format, args...), CallerOffset(1))
+	if e, ok := de.(*err); ok {
+		e.original = wrappedArg(format, args...)
+	}
+
+	return de
+}
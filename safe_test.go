@@ -0,0 +1,35 @@
+package errors
+
+import (
+	stderrors "errors"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+func TestNewfKeepsTheWrappedError(t *testing.T) {
+	de := Newf("read %s: %w", "config.yaml", io.ErrUnexpectedEOF)
+	if !stderrors.Is(de, io.ErrUnexpectedEOF) {
+		t.Errorf("expected errors.Is to find the %%w operand")
+	}
+
+	if de.Error() != "read config.yaml: unexpected EOF" {
+		t.Errorf("unexpected message %q", de.Error())
+	}
+
+	if de.SafeMessage() != "read "+UnsafeArgMask+": "+UnsafeArgMask {
+		t.Errorf("unexpected safe message %q", de.SafeMessage())
+	}
+
+	pathErr := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+	de = Newf("load: %w, fallback: %w", io.EOF, pathErr)
+
+	var target *fs.PathError
+	if !stderrors.As(de, &target) || !stderrors.Is(de, io.EOF) || !stderrors.Is(de, fs.ErrNotExist) {
+		t.Errorf("expected every %%w operand to be reachable")
+	}
+
+	if Newf("plain %d", 1).Unwrap() != nil {
+		t.Errorf("expected no original error without %%w")
+	}
+}
@@ -0,0 +1,238 @@
+package errors
+
+import (
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/poorly-written/grpc-http-response/codes"
+)
+
+type stackConfig struct {
+	dropPackages []string
+	keepModule   string
+	trimPrefixes []string
+	trimCache    bool
+	collapse     bool
+}
+
+// StackOption configures the filtered view of the stack trace returned by `StackTrace`,
+// which is also used by the formatting and the internal details
+type StackOption func(*stackConfig)
+
+// DropPackages drops the frames of the packages starting with any of the prefixes. e.g. "runtime", "testing"
+func DropPackages(prefixes ...string) StackOption {
+	return func(c *stackConfig) {
+		c.dropPackages = append(c.dropPackages, prefixes...)
+	}
+}
+
+// KeepModule keeps only the frames of the module, the main module of the binary is used when it's empty
+func KeepModule(module string) StackOption {
+	return func(c *stackConfig) {
+		if module == "" {
+			if bi, ok := debug.ReadBuildInfo(); ok {
+				module = bi.Main.Path
+			}
+		}
+
+		c.keepModule = module
+	}
+}
+
+// TrimModuleRoot makes the file paths under the directory relative to it. e.g. "/home/ci/src/app"
+func TrimModuleRoot(root string) StackOption {
+	return func(c *stackConfig) {
+		if root != "" {
+			c.trimPrefixes = append(c.trimPrefixes, strings.TrimSuffix(root, "/")+"/")
+		}
+	}
+}
+
+// TrimGOROOT makes the file paths of the standard library relative to GOROOT. e.g. "src/runtime/proc.go"
+func TrimGOROOT() StackOption {
+	return func(c *stackConfig) {
+		if root := goroot(); root != "" {
+			c.trimPrefixes = append(c.trimPrefixes, root)
+		}
+	}
+}
+
+// TrimModuleCache makes the file paths of the dependencies relative to the module cache.
+// e.g. "google.golang.org/grpc@v1.78.0/server.go"
+func TrimModuleCache() StackOption {
+	return func(c *stackConfig) {
+		c.trimCache = true
+	}
+}
+
+// CollapseRecursion replaces the consecutive frames of the same function with a single frame
+func CollapseRecursion() StackOption {
+	return func(c *stackConfig) {
+		c.collapse = true
+	}
+}
+
+// goroot is detected from the file of a runtime function, so it's correct for the binaries built elsewhere
+func goroot() string {
+	fn := runtime.FuncForPC(reflect.ValueOf(runtime.Gosched).Pointer())
+	if fn == nil {
+		return ""
+	}
+
+	file, _ := fn.FileLine(fn.Entry())
+	if idx := strings.LastIndex(file, "/src/runtime/"); idx >= 0 {
+		return file[:idx+1]
+	}
+
+	return ""
+}
+
+var stackOptions = &stackConfig{}
+var stackOptionsSetOnce sync.Once
+
+// SetStackOptions configures the filtered stack trace, the raw frames are still returned by `StackFrames`
+func SetStackOptions(opts ...StackOption) {
+	stackOptionsSetOnce.Do(func() {
+		stackOptions = newStackConfig(opts...)
+	})
+}
+
+func newStackConfig(opts ...StackOption) *stackConfig {
+	c := &stackConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *stackConfig) keep(d info) bool {
+	if c.keepModule != "" && d.Package != c.keepModule && !strings.HasPrefix(d.Package, c.keepModule+"/") {
+		return false
+	}
+
+	for _, prefix := range c.dropPackages {
+		if strings.HasPrefix(d.Package, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *stackConfig) trim(file string) string {
+	for _, prefix := range c.trimPrefixes {
+		if strings.HasPrefix(file, prefix) {
+			return strings.TrimPrefix(file, prefix)
+		}
+	}
+
+	if c.trimCache {
+		if idx := strings.Index(file, "/pkg/mod/"); idx >= 0 {
+			return file[idx+len("/pkg/mod/"):]
+		}
+	}
+
+	return file
+}
+
+func (c *stackConfig) apply(frames []frame) []info {
+	list := make([]info, 0, len(frames))
+	for _, f := range frames {
+		d := f.Details()
+		if !c.keep(d) {
+			continue
+		}
+
+		if last := len(list) - 1; c.collapse && last >= 0 && list[last].Package == d.Package && list[last].Name == d.Name {
+			list[last].Repeated++
+			continue
+		}
+
+		d.File = c.trim(d.File)
+		list = append(list, d)
+	}
+
+	return list
+}
+
+// StackTrace returns the details of the frames with the configured filters and trimming applied
+func (e *err) StackTrace() []info {
+	return e.factory.stackOptions().apply(e.frames)
+}
+
+type StackCaptureMode int
+
+const (
+	// CaptureFullStack captures up to the configured stack trace depth
+	CaptureFullStack StackCaptureMode = iota
+	// CaptureCaller captures only the frame of the caller
+	CaptureCaller
+	// CaptureNoStack skips the capture, it's the cheapest for the expected errors in the hot paths
+	CaptureNoStack
+)
+
+var defaultStackCapture = CaptureFullStack
+var defaultStackCaptureSetOnce sync.Once
+
+func SetDefaultStackCapture(mode StackCaptureMode) {
+	defaultStackCaptureSetOnce.Do(func() {
+		defaultStackCapture = mode
+	})
+}
+
+var stackCaptureByCode map[codes.Code]StackCaptureMode
+var stackCaptureByCodeSetOnce sync.Once
+
+// SetStackCaptureByCode overrides the default capture mode for the codes. e.g. CaptureNoStack for codes.BadRequest
+//
+// The stack is captured by `New`, so the mode is selected by the code passed with the ErrorCode option,
+// or by the code of a rehydrated status. The code set later with `Code` doesn't change the captured stack:
+//
+//	errors.New(nil, errors.ErrorCode(codes.BadRequest)) // the mode of codes.BadRequest
+//	errors.New(nil).Code(codes.BadRequest)               // the mode of the default error code
+func SetStackCaptureByCode(modes map[codes.Code]StackCaptureMode) {
+	stackCaptureByCodeSetOnce.Do(func() {
+		stackCaptureByCode = make(map[codes.Code]StackCaptureMode, len(modes))
+		for code, mode := range modes {
+			stackCaptureByCode[code] = mode
+		}
+	})
+}
+
+var callersPool = sync.Pool{
+	New: func() any {
+		return &[]uintptr{}
+	},
+}
+
+// captureStack skips the frames like runtime.Callers, relative to its caller.
+// Only the program counters are copied, they're symbolized when the frames are read.
+func captureStack(skip int, depth int, mode StackCaptureMode) []frame {
+	switch mode {
+	case CaptureNoStack:
+		return nil
+	case CaptureCaller:
+		depth = 1
+	}
+
+	buf := callersPool.Get().(*[]uintptr)
+	defer callersPool.Put(buf)
+
+	if len(*buf) < depth {
+		*buf = make([]uintptr, depth)
+	}
+
+	callers := (*buf)[:depth]
+	length := runtime.Callers(skip+1, callers)
+
+	frames := make([]frame, length)
+	for i, pc := range callers[:length] {
+		frames[i] = frame(pc)
+	}
+
+	return frames
+}
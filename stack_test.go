@@ -0,0 +1,178 @@
+package errors
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/status"
+)
+
+func BenchmarkNewStackCapture(b *testing.B) {
+	for _, bench := range []struct {
+		name string
+		mode StackCaptureMode
+	}{
+		{"FullStack", CaptureFullStack},
+		{"Caller", CaptureCaller},
+		{"NoStack", CaptureNoStack},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			f := NewFactory(WithStackCapture(bench.mode))
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = f.New(nil)
+			}
+		})
+	}
+}
+
+func TestNewNoStackAllocations(t *testing.T) {
+	f := NewFactory(WithStackCapture(CaptureNoStack))
+
+	// the error and its options, the maps and the ID are only created when they're used
+	if allocs := testing.AllocsPerRun(100, func() { _ = f.New(nil) }); allocs > 2 {
+		t.Errorf("expected at most 2 allocations, got %v", allocs)
+	}
+}
+
+const modulePath = "github.com/poorly-written/go-errors"
+
+// recurseStack creates the error after n recursive calls
+func recurseStack(f *Factory, n int) DetailedError {
+	if n == 0 {
+		return f.New(nil)
+	}
+
+	return recurseStack(f, n-1)
+}
+
+// statusCaller creates the error from the GRPCStatus method, so the frames of grpc are in the stack
+type statusCaller struct {
+	f  *Factory
+	de DetailedError
+}
+
+func (c *statusCaller) Error() string {
+	return "status caller"
+}
+
+func (c *statusCaller) GRPCStatus() *status.Status {
+	c.de = c.f.New(nil)
+
+	return nil
+}
+
+func TestStackOptions(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+
+	tests := []struct {
+		name  string
+		opts  []StackOption
+		check func(t *testing.T, trace []info)
+	}{
+		{
+			name: "drop packages",
+			opts: []StackOption{DropPackages("runtime", "testing")},
+			check: func(t *testing.T, trace []info) {
+				for _, d := range trace {
+					if strings.HasPrefix(d.Package, "runtime") || strings.HasPrefix(d.Package, "testing") {
+						t.Errorf("expected the frames of %s to be dropped", d.Package)
+					}
+				}
+			},
+		},
+		{
+			name: "keep module",
+			opts: []StackOption{KeepModule(modulePath)},
+			check: func(t *testing.T, trace []info) {
+				for _, d := range trace {
+					if d.Package != modulePath {
+						t.Errorf("expected only the frames of the module, got %s", d.Package)
+					}
+				}
+			},
+		},
+		{
+			name: "trim module root",
+			opts: []StackOption{TrimModuleRoot(filepath.Dir(file))},
+			check: func(t *testing.T, trace []info) {
+				if trace[0].File != "stack_test.go" {
+					t.Errorf("expected the path relative to the module root, got %s", trace[0].File)
+				}
+			},
+		},
+		{
+			name: "trim GOROOT",
+			opts: []StackOption{TrimGOROOT()},
+			check: func(t *testing.T, trace []info) {
+				var found bool
+				for _, d := range trace {
+					if d.Package == "testing" {
+						found = true
+						if d.File != "src/testing/testing.go" {
+							t.Errorf("expected the path relative to GOROOT, got %s", d.File)
+						}
+					}
+				}
+
+				if !found {
+					t.Errorf("expected the frames of the testing package")
+				}
+			},
+		},
+		{
+			name: "collapse recursion",
+			opts: []StackOption{CollapseRecursion()},
+			check: func(t *testing.T, trace []info) {
+				if trace[0].Name != "recurseStack" || trace[0].Repeated != 4 {
+					t.Errorf("expected the recursive calls to be collapsed, got %s repeated %d times", trace[0].Name, trace[0].Repeated)
+				}
+
+				if trace[1].Name == "recurseStack" {
+					t.Errorf("expected a single frame of the recursive function")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			de := recurseStack(NewFactory(WithStackOptions(tt.opts...)), 4)
+
+			trace := de.StackTrace()
+			if len(trace) == 0 {
+				t.Fatalf("expected the filtered stack trace to be non-empty")
+			}
+
+			tt.check(t, trace)
+
+			if len(de.StackFrames()) < len(trace) {
+				t.Errorf("expected the raw frames to be left untouched")
+			}
+		})
+	}
+}
+
+func TestStackOptionsTrimModuleCache(t *testing.T) {
+	c := &statusCaller{f: NewFactory(WithStackOptions(TrimModuleCache()))}
+	status.FromError(c)
+
+	var found bool
+	for _, d := range c.de.StackTrace() {
+		if d.Package != "google.golang.org/grpc/status" {
+			continue
+		}
+
+		found = true
+		if !strings.HasPrefix(d.File, "google.golang.org/grpc@") {
+			t.Errorf("expected the path relative to the module cache, got %s", d.File)
+		}
+	}
+
+	if !found {
+		t.Fatalf("expected the frames of grpc in the stack")
+	}
+}
@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"sync"
+)
+
+// TraceContext holds the W3C trace context the error was created in
+type TraceContext struct {
+	// TraceID is 32 lowercase hex characters
+	TraceID string
+	// SpanID is 16 lowercase hex characters
+	SpanID  string
+	Sampled bool
+}
+
+func (tc TraceContext) IsValid() bool {
+	return isValidHexID(tc.TraceID, 32) && isValidHexID(tc.SpanID, 16)
+}
+
+func isValidHexID(id string, length int) bool {
+	if len(id) != length || strings.Trim(id, "0") == "" || strings.ToLower(id) != id {
+		return false
+	}
+
+	_, err := hex.DecodeString(id)
+
+	return err == nil
+}
+
+// ParseTraceparent parses a W3C traceparent header. e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+func ParseTraceparent(traceparent string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 {
+		return TraceContext{}, false
+	}
+
+	version, flags := parts[0], parts[3]
+	if len(version) != 2 || version == "ff" || len(flags) != 2 {
+		return TraceContext{}, false
+	}
+
+	// version 00 has exactly 4 parts, the future versions may append more
+	if version == "00" && len(parts) != 4 {
+		return TraceContext{}, false
+	}
+
+	flagBytes, err := hex.DecodeString(flags)
+	if err != nil {
+		return TraceContext{}, false
+	}
+
+	tc := TraceContext{
+		TraceID: parts[1],
+		SpanID:  parts[2],
+		Sampled: flagBytes[0]&0x01 == 0x01,
+	}
+
+	if !tc.IsValid() {
+		return TraceContext{}, false
+	}
+
+	return tc, true
+}
+
+// TraceContextProvider supplies the trace context of a context.
+// The default one parses the traceparent, a tracing library bridge can supply the IDs of its spans instead.
+type TraceContextProvider interface {
+	TraceContext(ctx context.Context) (TraceContext, bool)
+}
+
+type TraceContextProviderFunc func(ctx context.Context) (TraceContext, bool)
+
+func (f TraceContextProviderFunc) TraceContext(ctx context.Context) (TraceContext, bool) {
+	return f(ctx)
+}
+
+// TraceparentProvider reads the traceparent from the incoming gRPC metadata,
+// or from the headers of the HTTP request stored with ContextWithHTTPRequest
+var TraceparentProvider TraceContextProvider = TraceContextProviderFunc(func(ctx context.Context) (TraceContext, bool) {
+	traceparent := incomingValue(ctx, traceparentKey)
+	if traceparent == "" {
+		return TraceContext{}, false
+	}
+
+	return ParseTraceparent(traceparent)
+})
+
+var traceContextProvider = TraceparentProvider
+var traceContextProviderSetOnce sync.Once
+
+func SetTraceContextProvider(provider TraceContextProvider) {
+	if provider == nil {
+		return
+	}
+
+	traceContextProviderSetOnce.Do(func() {
+		traceContextProvider = provider
+	})
+}
+
+func (f *Factory) traceContextFrom(ctx context.Context) *TraceContext {
+	if ctx == nil {
+		return nil
+	}
+
+	tc, ok := f.traceContextProvider().TraceContext(ctx)
+	if !ok || !tc.IsValid() {
+		return nil
+	}
+
+	// the copy is only moved to the heap when it's returned, the missing trace contexts don't allocate
+	valid := tc
+
+	return &valid
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	tests := []struct {
+		name        string
+		traceparent string
+		want        TraceContext
+		ok          bool
+	}{
+		{"sampled", "00-" + traceID + "-" + spanID + "-01", TraceContext{TraceID: traceID, SpanID: spanID, Sampled: true}, true},
+		{"not sampled", "00-" + traceID + "-" + spanID + "-00", TraceContext{TraceID: traceID, SpanID: spanID}, true},
+		{"surrounding spaces", " 00-" + traceID + "-" + spanID + "-01 ", TraceContext{TraceID: traceID, SpanID: spanID, Sampled: true}, true},
+		{"future version with more parts", "01-" + traceID + "-" + spanID + "-01-extra", TraceContext{TraceID: traceID, SpanID: spanID, Sampled: true}, true},
+		{"version ff", "ff-" + traceID + "-" + spanID + "-01", TraceContext{}, false},
+		{"invalid version", "0-" + traceID + "-" + spanID + "-01", TraceContext{}, false},
+		{"version 00 with more parts", "00-" + traceID + "-" + spanID + "-01-extra", TraceContext{}, false},
+		{"missing parts", "00-" + traceID + "-" + spanID, TraceContext{}, false},
+		{"all-zero trace ID", "00-00000000000000000000000000000000-" + spanID + "-01", TraceContext{}, false},
+		{"all-zero span ID", "00-" + traceID + "-0000000000000000-01", TraceContext{}, false},
+		{"uppercase trace ID", "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + spanID + "-01", TraceContext{}, false},
+		{"uppercase span ID", "00-" + traceID + "-00F067AA0BA902B7-01", TraceContext{}, false},
+		{"short trace ID", "00-4bf92f35-" + spanID + "-01", TraceContext{}, false},
+		{"non-hex span ID", "00-" + traceID + "-00f067aa0ba902bz-01", TraceContext{}, false},
+		{"invalid flags", "00-" + traceID + "-" + spanID + "-zz", TraceContext{}, false},
+		{"empty", "", TraceContext{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseTraceparent(tt.traceparent)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("expected %+v, %v, got %+v, %v", tt.want, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestSetTraceContextProvider(t *testing.T) {
+	defer func(provider TraceContextProvider) {
+		traceContextProvider = provider
+		traceContextProviderSetOnce = sync.Once{}
+	}(traceContextProvider)
+
+	tc := TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}
+	provider := func(tc TraceContext) TraceContextProvider {
+		return TraceContextProviderFunc(func(context.Context) (TraceContext, bool) {
+			return tc, true
+		})
+	}
+
+	// a nil provider is ignored, so it doesn't consume the single call
+	SetTraceContextProvider(nil)
+	SetTraceContextProvider(provider(tc))
+	SetTraceContextProvider(provider(TraceContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"}))
+
+	if got, ok := New(nil, Context(context.Background())).TraceContext(); !ok || got != tc {
+		t.Errorf("expected the trace context of the first provider, got %+v", got)
+	}
+
+	invalid := NewFactory(WithTraceContextProvider(provider(TraceContext{TraceID: "invalid"})))
+	if _, ok := invalid.New(nil).TraceContext(); ok {
+		t.Errorf("expected the invalid trace contexts of the provider to be dropped")
+	}
+}
+
+func TestTraceContextRoundTrip(t *testing.T) {
+	sent := TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true}
+	local := TraceContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331", Sampled: true}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(traceparentKey, "00-"+sent.TraceID+"-"+sent.SpanID+"-01"))
+	localCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(traceparentKey, "00-"+local.TraceID+"-"+local.SpanID+"-01"))
+
+	for _, version := range []DetailsVersion{DetailsV1, DetailsV2, DetailsV1AndV2} {
+		src := NewFactory(WithDetailsVersion(version)).New(nil, Context(ctx))
+		if src.TraceID() != sent.TraceID || src.SpanID() != sent.SpanID {
+			t.Fatalf("v%d: expected the trace context of the traceparent, got %s/%s", version, src.TraceID(), src.SpanID())
+		}
+
+		// the sampled flag isn't sent
+		received := New(src.GRPCStatus().Err())
+		if got, ok := received.TraceContext(); !ok || got != (TraceContext{TraceID: sent.TraceID, SpanID: sent.SpanID}) {
+			t.Errorf("v%d: expected the trace context to be received, got %+v", version, got)
+		}
+
+		if got, _ := New(src.GRPCStatus().Err(), Context(localCtx)).TraceContext(); got != local {
+			t.Errorf("v%d: expected the local trace context to take precedence, got %+v", version, got)
+		}
+
+		if _, ok := New(NewFactory(WithDetailsVersion(version)).New(nil).GRPCStatus().Err()).TraceContext(); ok {
+			t.Errorf("v%d: expected no trace context to be received without one", version)
+		}
+	}
+}
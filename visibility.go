@@ -0,0 +1,26 @@
+package errors
+
+import "sync"
+
+// Visibility controls who can see a metadata key. Levels are ordered,
+// an audience sees every key with a visibility lower than or equal to its own.
+type Visibility int
+
+const (
+	// VisibilityPublic metadata is sent to the clients
+	VisibilityPublic Visibility = iota
+	// VisibilityInternal metadata is sent to the trusted downstream services, when `IncludeMetadata` is called
+	VisibilityInternal
+	// VisibilityLogOnly metadata never leaves the process, it's only available to the loggers and reporters
+	VisibilityLogOnly
+)
+
+var defaultMetadataVisibility = VisibilityInternal
+var defaultMetadataVisibilitySetOnce sync.Once
+
+// SetDefaultMetadataVisibility sets the visibility of the metadata added without an explicit one
+func SetDefaultMetadataVisibility(visibility Visibility) {
+	defaultMetadataVisibilitySetOnce.Do(func() {
+		defaultMetadataVisibility = visibility
+	})
+}
@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"testing"
+)
+
+func TestRehydratedMetadataVisibility(t *testing.T) {
+	// the receiver forwards its own metadata to the clients by default
+	receiver := NewFactory(WithDefaultMetadataVisibility(VisibilityPublic))
+
+	for _, version := range []DetailsVersion{DetailsV1, DetailsV2} {
+		f := NewFactory(WithDetailsVersion(version))
+		src := f.New(nil).
+			IncludeMetadata().
+			AddMetadataWithVisibility("user_id", 1, VisibilityPublic).
+			AddMetadataWithVisibility("sql", "select 1", VisibilityInternal)
+
+		de := receiver.New(src.GRPCStatus().Err())
+
+		if de.MetadataVisibility("user_id") != VisibilityPublic {
+			t.Errorf("v%d: expected the public key to stay public", version)
+		}
+
+		if de.MetadataVisibility("sql") != VisibilityInternal {
+			t.Errorf("v%d: expected the internal key to be marked internal, got %d", version, de.MetadataVisibility("sql"))
+		}
+
+		if public := de.GetMetadataFor(VisibilityPublic); len(public) != 1 || public["sql"] != nil {
+			t.Errorf("v%d: expected only the public key to be forwarded to the clients, got %v", version, public)
+		}
+	}
+}